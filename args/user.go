@@ -26,6 +26,11 @@ func (m *UserMention) Format(field string) string {
 	return "<" + field + ": @user>"
 }
 
+// OptionType returns the type of the argument's application command option, a user option.
+func (m *UserMention) OptionType() int {
+	return 6
+}
+
 // Snowflake returns a disgord.Snowflake for the User Mention.
 func (m *UserMention) Snowflake() disgord.Snowflake {
 	return disgord.ParseSnowflakeString(string(*m))
@@ -33,6 +33,14 @@ type Formatter interface {
 	Format(field string) string
 }
 
+// OptionTyper represents an argument that sets the type of it's option on
+// platforms with structured commands, such as the type of a Discord application
+// command option. Arguments that do not implement OptionTyper use the option
+// type matching their Kind.
+type OptionTyper interface {
+	OptionType() int
+}
+
 // argumentValueFn represents an argument value function.
 type argumentValueFn func(string) (reflect.Value, error)
 
@@ -146,13 +154,85 @@ func floatArgumentValue(t reflect.Type) argumentValueFn {
 
 func boolArgumentValue() argumentValueFn {
 	return func(input string) (reflect.Value, error) {
-		switch strings.ToLower(input) {
-		case "true", "yes", "y", "1":
-			return reflect.ValueOf(true), nil
-		case "false", "no", "n", "0":
-			return reflect.ValueOf(false), nil
-		default:
-			return nilV, ErrInvalidBool
+		b, err := ParseBool(input)
+		if err != nil {
+			return nilV, err
 		}
+
+		return reflect.ValueOf(b), nil
+	}
+}
+
+// ParseBool parses a bool argument, accepting true/false, yes/no, y/n and 1/0.
+func ParseBool(input string) (bool, error) {
+	switch strings.ToLower(input) {
+	case "true", "yes", "y", "1":
+		return true, nil
+	case "false", "no", "n", "0":
+		return false, nil
+	default:
+		return false, ErrInvalidBool
+	}
+}
+
+// getOptionType returns the option type set by an argument's type, zero is
+// returned if the type does not implement OptionTyper.
+func getOptionType(t reflect.Type) int {
+	if !t.Implements(typeIOptionTyper) {
+		return 0
+	}
+
+	var v reflect.Value
+	if t.Kind() == reflect.Ptr {
+		v = reflect.New(t.Elem())
+	} else {
+		v = reflect.Zero(t)
+	}
+
+	return v.Interface().(OptionTyper).OptionType()
+}
+
+// getArgumentKind returns the kind of value an argument's type is parsed from,
+// parseable arguments are parsed from a string.
+func getArgumentKind(t reflect.Type) reflect.Kind {
+	if t.Implements(typeIParseable) || t.Implements(typeIManualParseable) {
+		return reflect.String
+	}
+
+	return t.Kind()
+}
+
+// getGeneratedKind returns the kind of the type of a generated argument, only
+// builtin types are recognized and everything else is parsed from a string.
+func getGeneratedKind(t string) reflect.Kind {
+	switch t {
+	case "int":
+		return reflect.Int
+	case "int8":
+		return reflect.Int8
+	case "int16":
+		return reflect.Int16
+	case "int32":
+		return reflect.Int32
+	case "int64":
+		return reflect.Int64
+	case "uint":
+		return reflect.Uint
+	case "uint8":
+		return reflect.Uint8
+	case "uint16":
+		return reflect.Uint16
+	case "uint32":
+		return reflect.Uint32
+	case "uint64":
+		return reflect.Uint64
+	case "float32":
+		return reflect.Float32
+	case "float64":
+		return reflect.Float64
+	case "bool":
+		return reflect.Bool
+	default:
+		return reflect.String
 	}
 }
@@ -0,0 +1,170 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	// ErrMissingCommandName is returned by CommandBuilder#Build when the command has no name.
+	ErrMissingCommandName = errors.New("router: missing command name")
+	// ErrMissingHandler is returned by CommandBuilder#Build when the command has no handler.
+	ErrMissingHandler = errors.New("router: missing command handler")
+	// ErrHandlerIsNotAFunction is returned by CommandBuilder#Build when the handler is not a function.
+	ErrHandlerIsNotAFunction = errors.New("router: handler is not a function")
+)
+
+// CommandBuilder builds a *Command from a function, allowing closures and generated
+// code to be registered without a registrar.
+//
+//	command, err := router.NewCommand("ban").
+//		Description("Bans a user.").
+//		Arg("user", new(args.UserMention)).
+//		Handler(func(ctx *router.Context, user *args.UserMention) error {
+//			return nil
+//		}).
+//		Build()
+type CommandBuilder struct {
+	name        string
+	description string
+	metadata    Metadata
+	arguments   []builderArgument
+	handler     interface{}
+}
+
+// builderArgument represents an argument added using CommandBuilder#Arg.
+type builderArgument struct {
+	name string
+	t    reflect.Type
+}
+
+// NewCommand returns a new CommandBuilder for a command with the given name.
+func NewCommand(name string) *CommandBuilder {
+	return &CommandBuilder{
+		name: strings.ToLower(name),
+	}
+}
+
+// Description sets the command's description.
+func (b *CommandBuilder) Description(description string) *CommandBuilder {
+	b.description = description
+	return b
+}
+
+// Metadata sets the command's metadata.
+func (b *CommandBuilder) Metadata(metadata Metadata) *CommandBuilder {
+	b.metadata = metadata
+	return b
+}
+
+// Arg adds an argument to the command, arguments must be added in the same order
+// as the handler's parameters. v is a value of the argument's type, either the type
+// of the handler's parameter or the type it points to, for example "", 0 or
+// new(args.UserMention).
+func (b *CommandBuilder) Arg(name string, v interface{}) *CommandBuilder {
+	b.arguments = append(b.arguments, builderArgument{
+		name: name,
+		t:    reflect.TypeOf(v),
+	})
+	return b
+}
+
+// Handler sets the function called when the command is used. The handler takes
+// the same parameters and returns the same values as a registrar method, without
+// the receiver.
+func (b *CommandBuilder) Handler(fn interface{}) *CommandBuilder {
+	b.handler = fn
+	return b
+}
+
+// Build validates the command and returns the *Command, the command can be added
+// to a router using Router#RegisterCommands.
+func (b *CommandBuilder) Build() (*Command, error) {
+	if b.name == "" {
+		return nil, ErrMissingCommandName
+	}
+
+	if b.handler == nil {
+		return nil, ErrMissingHandler
+	}
+
+	value := reflect.ValueOf(b.handler)
+	t := value.Type()
+	if t.Kind() != reflect.Func {
+		return nil, ErrHandlerIsNotAFunction
+	}
+
+	// Check if the handler returns an error, optionally preceded by a reply.
+	if !isValidReturn(t) {
+		return nil, ErrMethodHasNoErrorReturn
+	}
+
+	// offset is the index of the first command argument.
+	sig, platform, offset := getSignature(t, 0)
+	if offset < 0 {
+		return nil, ErrMissingMessageCreateArgument
+	}
+
+	names := make([]string, len(b.arguments))
+	for i, argument := range b.arguments {
+		names[i] = argument.name
+
+		if offset+i >= t.NumIn() {
+			continue
+		}
+
+		// Allow the argument's type to be the type the handler's parameter points to.
+		want := t.In(offset + i)
+		if argument.t != want && (argument.t == nil || reflect.PtrTo(argument.t) != want) {
+			return nil, fmt.Errorf("router: %s's argument %s does not match the handler's parameter %s", b.name, argument.name, want.String())
+		}
+	}
+
+	command := newCommand(b.name, value, sig)
+	command.platform = platform
+	command.Description = b.description
+	if err := command.setMetadata(b.metadata); err != nil {
+		return nil, err
+	}
+
+	if err := command.setArguments(b.name, t, offset, names, b.metadata.ArgumentDescriptions); err != nil {
+		return nil, err
+	}
+
+	if err := command.setOptional(b.name, b.metadata.Optional); err != nil {
+		return nil, err
+	}
+
+	if err := command.setDefaults(b.name, b.metadata.Defaults); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+// RegisterCommands registers commands created using a CommandBuilder, no commands
+// are registered if any of their names or aliases are already registered.
+//
+// RegisterCommands is safe to call while the router is handling events.
+func (r *Router) RegisterCommands(commands ...*Command) error {
+	for _, command := range commands {
+		if command == nil {
+			return ErrCommandIsNil
+		}
+	}
+
+	if err := r.checkStrict(nil, commands); err != nil {
+		return err
+	}
+
+	return r.addCommands(commands)
+}
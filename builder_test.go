@@ -0,0 +1,131 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router/args"
+	"testing"
+)
+
+type banRegistrar struct{}
+
+func (c *banRegistrar) Ban(_ *Context, _ *args.UserMention, _ int) error {
+	return nil
+}
+
+func (c *banRegistrar) Descriptions() map[string]string {
+	return map[string]string{
+		"ban": "Bans a user.",
+	}
+}
+
+func (c *banRegistrar) Arguments() map[string][]string {
+	return map[string][]string{
+		"ban": {"user", "days"},
+	}
+}
+
+func TestNewCommand(t *testing.T) {
+	t.Run("Build", func(t *testing.T) {
+		a := assert.New(t)
+
+		var user string
+		var days int
+		command, err := NewCommand("Ban").
+			Description("Bans a user.").
+			Arg("user", new(args.UserMention)).
+			Arg("days", 0).
+			Metadata(Metadata{Aliases: []string{"b"}}).
+			Handler(func(_ *Context, u *args.UserMention, d int) error {
+				user, days = string(*u), d
+				return nil
+			}).
+			Build()
+		if !a.NoError(err) {
+			return
+		}
+
+		// The command must match the command created from a registrar method.
+		router, err := New(prefix, &banRegistrar{})
+		if !a.NoError(err) {
+			return
+		}
+
+		expected := router.GetCommandByName("ban")
+		a.Equal(expected.Name(), command.Name())
+		a.Equal(expected.Description, command.Description)
+		a.Equal(expected.Usage(), command.Usage())
+		a.Equal(expected.Arguments(), command.Arguments())
+		a.Equal([]string{"b"}, command.Aliases())
+
+		router, err = New(prefix, &commands{})
+		if !a.NoError(err) {
+			return
+		}
+
+		a.NoError(router.RegisterCommands(command))
+		a.NoError(handle(router, newTestEvent(prefix+"b <@1234> 7")))
+		a.Equal("1234", user)
+		a.Equal(7, days)
+		a.Error(router.RegisterCommands(command))
+	})
+
+	t.Run("Optional", func(t *testing.T) {
+		a := assert.New(t)
+
+		days := -1
+		command, err := NewCommand("kick").
+			Arg("user", new(args.UserMention)).
+			Arg("days", 0).
+			Metadata(Metadata{Optional: []string{"days"}}).
+			Handler(func(_ *Context, _ *args.UserMention, d int) error {
+				days = d
+				return nil
+			}).
+			Build()
+		if !a.NoError(err) {
+			return
+		}
+
+		a.Equal(" <user: @user> [days: int]", command.Usage())
+
+		router, err := New(prefix, &commands{})
+		if !a.NoError(err) || !a.NoError(router.RegisterCommands(command)) {
+			return
+		}
+
+		a.NoError(handle(router, newTestEvent(prefix+"kick <@1234>")))
+		a.Equal(0, days)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		a := assert.New(t)
+
+		_, err := NewCommand("").Handler(func(_ *testEvent) error { return nil }).Build()
+		a.Equal(ErrMissingCommandName, err)
+
+		_, err = NewCommand("ping").Build()
+		a.Equal(ErrMissingHandler, err)
+
+		_, err = NewCommand("ping").Handler("pong").Build()
+		a.Equal(ErrHandlerIsNotAFunction, err)
+
+		_, err = NewCommand("ping").Handler(func(_ *testEvent) {}).Build()
+		a.Equal(ErrMethodHasNoErrorReturn, err)
+
+		_, err = NewCommand("ping").Handler(func() error { return nil }).Build()
+		a.Equal(ErrMissingMessageCreateArgument, err)
+
+		_, err = NewCommand("ping").Handler(func(_ *testEvent, _ int) error { return nil }).Build()
+		a.Error(err)
+
+		_, err = NewCommand("ping").Arg("count", "").Handler(func(_ *testEvent, _ int) error { return nil }).Build()
+		a.Error(err)
+	})
+}
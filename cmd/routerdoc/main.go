@@ -0,0 +1,93 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+// Command routerdoc renders the command reference of a router's manifest as
+// Markdown or HTML, grouped by category.
+//
+// Usage:
+//
+//	routerdoc [-format=markdown|html] [-output=file] [manifest.json]
+//
+// The manifest is the JSON returned by router.Manifest's JSON method, it's read
+// from standard input if no file is given.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go.matthewp.io/router"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	format := flag.String("format", "markdown", "output format, markdown or html")
+	output := flag.String("output", "", "output file name, defaults to standard output")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: routerdoc [-format=markdown|html] [-output=file] [manifest.json]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	in := io.Reader(os.Stdin)
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	if err := run(in, *output, *format); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run renders the manifest read from in, writing it to the output file or
+// standard output if output is empty. The output file is only written once the
+// manifest has been rendered, so an invalid manifest or format leaves it untouched.
+func run(in io.Reader, output, format string) error {
+	var b bytes.Buffer
+	if err := render(in, &b, format); err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err := os.Stdout.Write(b.Bytes())
+		return err
+	}
+
+	return ioutil.WriteFile(output, b.Bytes(), 0644)
+}
+
+// render decodes the manifest read from r and writes it's command reference to w.
+func render(r io.Reader, w io.Writer, format string) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	manifest := &router.Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return fmt.Errorf("routerdoc: failed to parse manifest: %v", err)
+	}
+
+	switch format {
+	case "markdown", "md":
+		return manifest.Markdown(w)
+	case "html":
+		return manifest.HTML(w)
+	default:
+		return fmt.Errorf("routerdoc: unknown format %q", format)
+	}
+}
@@ -0,0 +1,81 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const manifest = `{
+	"prefix": ".",
+	"commands": [
+		{"name": "ping", "description": "Checks if the bot is online", "usage": ""},
+		{"name": "secret", "usage": "", "hidden": true}
+	]
+}`
+
+func TestRender(t *testing.T) {
+	t.Run("Markdown", func(t *testing.T) {
+		a := assert.New(t)
+
+		var b bytes.Buffer
+		a.NoError(render(strings.NewReader(manifest), &b, "markdown"))
+		a.Equal("# Commands\n\n## Other\n\n### .ping\n\nChecks if the bot is online\n\n**Usage:** `.ping`\n", b.String())
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		a := assert.New(t)
+
+		var b bytes.Buffer
+		a.NoError(render(strings.NewReader(manifest), &b, "html"))
+		a.Contains(b.String(), "<h3>.ping</h3>")
+		a.NotContains(b.String(), "secret")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		a := assert.New(t)
+
+		a.EqualError(render(strings.NewReader(manifest), &bytes.Buffer{}, "pdf"), `routerdoc: unknown format "pdf"`)
+		a.Error(render(strings.NewReader("{"), &bytes.Buffer{}, "html"))
+	})
+}
+
+func TestRun(t *testing.T) {
+	a := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "routerdoc")
+	if !a.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	output := filepath.Join(dir, "commands.md")
+	if !a.NoError(ioutil.WriteFile(output, []byte("existing"), 0644)) {
+		return
+	}
+
+	// The output is left untouched if the manifest cannot be rendered.
+	a.Error(run(strings.NewReader(manifest), output, "pdf"))
+	a.Error(run(strings.NewReader("{"), output, "markdown"))
+
+	data, err := ioutil.ReadFile(output)
+	a.NoError(err)
+	a.Equal("existing", string(data))
+
+	a.NoError(run(strings.NewReader(manifest), output, "markdown"))
+
+	data, err = ioutil.ReadFile(output)
+	a.NoError(err)
+	a.True(strings.HasPrefix(string(data), "# Commands\n"))
+}
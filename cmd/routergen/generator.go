@@ -0,0 +1,647 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	routerPath  = "go.matthewp.io/router"
+	discordPath = "go.matthewp.io/router/discord"
+	disgordPath = "github.com/andersfylling/disgord"
+)
+
+// signature represents the leading parameters of a command method, before the command's arguments.
+type signature int
+
+const (
+	signatureMessageCreate signature = iota
+	signatureContextMessageCreate
+	signatureContext
+	signatureMessage
+	signatureContextMessage
+)
+
+// argumentParser represents the kind of code used to parse an argument.
+type argumentParser int
+
+const (
+	parserParseable argumentParser = iota
+	parserManualParseable
+	parserString
+	parserInt
+	parserUint
+	parserFloat
+	parserBool
+)
+
+// command represents a command method on the registrar.
+type command struct {
+	method    string
+	signature signature
+	reply     bool
+	arguments []*argument
+}
+
+// argument represents an argument of a command method.
+type argument struct {
+	// expr is the argument's type in the generated code.
+	expr string
+	// elem is the type the argument points to in the generated code, only set for pointers.
+	elem string
+	// usage is the argument's type as it is shown in the command's usage.
+	usage string
+
+	parser    argumentParser
+	formatter bool
+	// kind is the name of the argument's reflect.Kind, empty if the argument is
+	// parsed from a string.
+	kind string
+
+	// optionTyper is true if the argument implements router.OptionTyper.
+	optionTyper bool
+}
+
+// listedPackage represents a package printed by `go list -json`.
+type listedPackage struct {
+	ImportPath string
+	Name       string
+	Dir        string
+	Export     string
+	GoFiles    []string
+	DepOnly    bool
+	Error      *struct {
+		Err string
+	}
+}
+
+// generator generates the dispatch code for a registrar.
+type generator struct {
+	pkg      *types.Package
+	importer types.Importer
+
+	// imports maps the path of every package used by the generated code to it's name.
+	imports map[string]string
+
+	parseable       *types.Interface
+	manualParseable *types.Interface
+	formatter       *types.Interface
+	optionTyper     *types.Interface
+	response        *types.Interface
+}
+
+// generate returns the generated source for the registrar type in the package in dir,
+// output is excluded from the package when it is type checked.
+func generate(dir string, typeName string, output string) ([]byte, error) {
+	pkg, imp, err := loadPackage(dir, output)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		pkg:      pkg,
+		importer: imp,
+
+		imports: make(map[string]string),
+	}
+
+	if err := g.loadInterfaces(); err != nil {
+		return nil, err
+	}
+
+	obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("routergen: type %s not found in %s", typeName, pkg.Path())
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("routergen: %s is not a named type", typeName)
+	}
+
+	commands, err := g.getCommands(named)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.render(named, commands)
+}
+
+// loadPackage type checks the package in dir using the export data of it's dependencies.
+func loadPackage(dir string, output string) (*types.Package, types.Importer, error) {
+	cmd := exec.Command("go", "list", "-e", "-export", "-deps", "-json", ".")
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// The package itself may fail to build while it's generated file is out of
+	// date, so errors are ignored as long as go list printed the packages.
+	out, err := cmd.Output()
+	if len(out) < 1 && err != nil {
+		return nil, nil, fmt.Errorf("routergen: go list: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	exports := make(map[string]string)
+	var target *listedPackage
+
+	decoder := json.NewDecoder(bytes.NewReader(out))
+	for {
+		p := &listedPackage{}
+		if err := decoder.Decode(p); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("routergen: go list: %v", err)
+		}
+
+		if p.DepOnly {
+			exports[p.ImportPath] = p.Export
+			continue
+		}
+
+		target = p
+	}
+
+	if target == nil {
+		return nil, nil, fmt.Errorf("routergen: no package found in %s", dir)
+	}
+
+	if len(target.GoFiles) < 1 && target.Error != nil {
+		return nil, nil, fmt.Errorf("routergen: %s", target.Error.Err)
+	}
+
+	outputPath, err := filepath.Abs(output)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(target.GoFiles))
+	for _, name := range target.GoFiles {
+		path := filepath.Join(target.Dir, name)
+		if path == outputPath {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	imp := importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+		export := exports[path]
+		if export == "" {
+			return nil, fmt.Errorf("no export data for %s", path)
+		}
+
+		return os.Open(export)
+	})
+
+	config := &types.Config{
+		Importer: imp,
+	}
+
+	pkg, err := config.Check(target.ImportPath, fset, files, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("routergen: %v", err)
+	}
+
+	return pkg, imp, nil
+}
+
+// loadInterfaces loads the router's argument and reply interfaces.
+func (g *generator) loadInterfaces() error {
+	pkg, err := g.importer.Import(routerPath)
+	if err != nil {
+		return fmt.Errorf("routergen: %s is not imported by %s: %v", routerPath, g.pkg.Path(), err)
+	}
+
+	lookup := func(name string) *types.Interface {
+		return pkg.Scope().Lookup(name).Type().Underlying().(*types.Interface)
+	}
+
+	g.parseable = lookup("Parseable")
+	g.manualParseable = lookup("ManualParseable")
+	g.formatter = lookup("Formatter")
+	g.optionTyper = lookup("OptionTyper")
+
+	// Response is only known if the discord package is imported.
+	if discord, err := g.importer.Import(discordPath); err == nil {
+		g.response = discord.Scope().Lookup("Response").Type().Underlying().(*types.Interface)
+	}
+	return nil
+}
+
+// getCommands returns the command methods on the registrar's pointer type, in
+// the same order and using the same rules as the router's reflection.
+func (g *generator) getCommands(named *types.Named) ([]*command, error) {
+	methods := types.NewMethodSet(types.NewPointer(named))
+
+	var commands []*command
+	for i := 0; i < methods.Len(); i++ {
+		fn := methods.At(i).Obj().(*types.Func)
+		if !fn.Exported() {
+			continue
+		}
+
+		sig := fn.Type().(*types.Signature)
+		name := named.Obj().Name() + "." + fn.Name()
+		s, offset := getSignature(sig.Params())
+		if offset < 0 {
+			continue
+		}
+
+		if sig.Variadic() {
+			return nil, fmt.Errorf("routergen: %s is variadic", name)
+		}
+
+		reply, ok := g.getReply(sig.Results())
+		if !ok {
+			return nil, fmt.Errorf("routergen: %s does not return an error or a reply and an error", name)
+		}
+
+		c := &command{
+			method:    fn.Name(),
+			signature: s,
+			reply:     reply,
+		}
+
+		for j := offset; j < sig.Params().Len(); j++ {
+			a, err := g.getArgument(sig.Params().At(j).Type())
+			if err != nil {
+				return nil, fmt.Errorf("routergen: %s's argument %d: %v", name, j-offset, err)
+			}
+
+			c.arguments = append(c.arguments, a)
+		}
+
+		commands = append(commands, c)
+	}
+
+	sort.SliceStable(commands, func(i, j int) bool {
+		return commands[i].method < commands[j].method
+	})
+
+	return commands, nil
+}
+
+// getSignature returns the signature of a method and the index of it's first
+// command argument, the index is -1 if the method is not a valid command.
+func getSignature(params *types.Tuple) (signature, int) {
+	if params.Len() < 1 {
+		return 0, -1
+	}
+
+	switch types.TypeString(params.At(0).Type(), nil) {
+	case "*" + disgordPath + ".MessageCreate":
+		return signatureMessageCreate, 1
+	case "*" + routerPath + ".Context":
+		return signatureContext, 1
+	case routerPath + ".Message":
+		return signatureMessage, 1
+	case "context.Context":
+		if params.Len() < 2 {
+			break
+		}
+
+		switch types.TypeString(params.At(1).Type(), nil) {
+		case "*" + disgordPath + ".MessageCreate":
+			return signatureContextMessageCreate, 2
+		case routerPath + ".Message":
+			return signatureContextMessage, 2
+		}
+	}
+
+	return 0, -1
+}
+
+// getReply checks if a method's results are either an error or a reply followed
+// by an error, reply is true if the method returns a reply.
+func (g *generator) getReply(results *types.Tuple) (reply bool, ok bool) {
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+	switch results.Len() {
+	case 1:
+		return false, types.Implements(results.At(0).Type(), errorType)
+	case 2:
+		if !types.Implements(results.At(1).Type(), errorType) {
+			return false, false
+		}
+
+		t := results.At(0).Type()
+		switch types.TypeString(t, nil) {
+		case "string", "*" + disgordPath + ".Embed", "*" + disgordPath + ".CreateMessageParams":
+			return true, true
+		}
+
+		return true, g.response != nil && types.Implements(t, g.response)
+	default:
+		return false, false
+	}
+}
+
+// getArgument returns the argument for a parameter type.
+func (g *generator) getArgument(t types.Type) (*argument, error) {
+	a := &argument{
+		expr: types.TypeString(t, g.qualifier),
+		usage: types.TypeString(t, func(p *types.Package) string {
+			return p.Name()
+		}),
+
+		formatter:   types.Implements(t, g.formatter),
+		optionTyper: types.Implements(t, g.optionTyper),
+	}
+
+	switch {
+	case types.Implements(t, g.parseable):
+		a.parser = parserParseable
+	case types.Implements(t, g.manualParseable):
+		a.parser = parserManualParseable
+	default:
+		basic, ok := t.Underlying().(*types.Basic)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %s", a.usage)
+		}
+
+		switch basic.Kind() {
+		case types.String:
+			a.parser = parserString
+		case types.Int, types.Int8, types.Int16, types.Int32, types.Int64:
+			a.parser = parserInt
+		case types.Uint, types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+			a.parser = parserUint
+		case types.Float32, types.Float64:
+			a.parser = parserFloat
+		case types.Bool:
+			a.parser = parserBool
+		default:
+			return nil, fmt.Errorf("unsupported type %s", a.usage)
+		}
+
+		// The names of the basic types match the names of their reflect.Kind.
+		if a.parser != parserString {
+			a.kind = strings.Title(basic.Name())
+		}
+
+		if a.formatter {
+			return nil, fmt.Errorf("%s must be a pointer to implement router.Formatter", a.usage)
+		}
+
+		if a.optionTyper {
+			return nil, fmt.Errorf("%s must be a pointer to implement router.OptionTyper", a.usage)
+		}
+
+		return a, nil
+	}
+
+	// Parseable arguments are created using new, the same as reflect.New.
+	pointer, ok := t.(*types.Pointer)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a pointer", a.usage)
+	}
+	a.elem = types.TypeString(pointer.Elem(), g.qualifier)
+
+	return a, nil
+}
+
+// qualifier returns the name used for a package in the generated code, adding it to the imports.
+func (g *generator) qualifier(p *types.Package) string {
+	if p.Path() == g.pkg.Path() {
+		return ""
+	}
+
+	return g.use(p.Path(), p.Name())
+}
+
+// use adds a package to the imports, returning the name it can be referenced by.
+func (g *generator) use(path string, name string) string {
+	if n, ok := g.imports[path]; ok {
+		return n
+	}
+
+	taken := func(n string) bool {
+		for _, used := range g.imports {
+			if used == n {
+				return true
+			}
+		}
+
+		return g.pkg.Scope().Lookup(n) != nil
+	}
+
+	n := name
+	for i := 2; taken(n); i++ {
+		n = name + strconv.Itoa(i)
+	}
+
+	g.imports[path] = n
+	return n
+}
+
+// render renders the generated file.
+func (g *generator) render(named *types.Named, commands []*command) ([]byte, error) {
+	routerName := g.use(routerPath, "router")
+	typeName := named.Obj().Name()
+	receiver := getReceiverName(named)
+
+	var body bytes.Buffer
+	p := func(format string, args ...interface{}) {
+		fmt.Fprintf(&body, format+"\n", args...)
+	}
+
+	p("// RouterCommands returns the generated dispatch code for the commands on *%s,", typeName)
+	p("// it implements %s.GeneratedRegistrar.", routerName)
+	p("func (%s *%s) RouterCommands() []%s.GeneratedCommand {", receiver, typeName, routerName)
+	p("return []%s.GeneratedCommand{", routerName)
+	for _, c := range commands {
+		p("{")
+		p("Method: %q,", c.method)
+		if c.signature == signatureMessageCreate || c.signature == signatureContextMessageCreate {
+			p("Event: %s.TypeOf((*%s.MessageCreate)(nil)),", g.use("reflect", "reflect"), g.use(disgordPath, "disgord"))
+		}
+
+		if len(c.arguments) > 0 {
+			p("Arguments: []%s.GeneratedArgument{", routerName)
+			for _, a := range c.arguments {
+				p("{")
+				p("Type: %q,", a.usage)
+				if a.parser == parserManualParseable {
+					p("Raw: true,")
+				}
+				p("Parse: func(input string) (interface{}, error) {")
+				g.renderParse(p, routerName, a)
+				p("},")
+				if a.formatter {
+					p("Format: func(name string) string {")
+					p("return new(%s).Format(name)", a.elem)
+					p("},")
+				}
+				if a.kind != "" {
+					p("Kind: %s.%s,", g.use("reflect", "reflect"), a.kind)
+				}
+				if a.optionTyper {
+					p("Option: new(%s).OptionType(),", a.elem)
+				}
+				p("},")
+			}
+			p("},")
+		}
+
+		p("Call: func(ctx *%s.Context, values []interface{}) (interface{}, error) {", routerName)
+		params := make([]string, 0, len(c.arguments)+2)
+		switch c.signature {
+		case signatureMessageCreate:
+			p("e, _ := ctx.Event().(*%s.MessageCreate)", g.use(disgordPath, "disgord"))
+			params = append(params, "e")
+		case signatureContextMessageCreate:
+			p("e, _ := ctx.Event().(*%s.MessageCreate)", g.use(disgordPath, "disgord"))
+			params = append(params, "ctx.Context", "e")
+		case signatureContext:
+			params = append(params, "ctx")
+		case signatureMessage:
+			params = append(params, "ctx.Message")
+		case signatureContextMessage:
+			params = append(params, "ctx.Context", "ctx.Message")
+		}
+		for i, a := range c.arguments {
+			switch a.parser {
+			case parserParseable, parserManualParseable:
+				// Omitted optional arguments are nil, the command receives an empty value instead.
+				p("a%d, ok := values[%d].(%s)", i, i, a.expr)
+				p("if !ok {")
+				p("a%d = new(%s)", i, a.elem)
+				p("}")
+			default:
+				p("a%d, _ := values[%d].(%s)", i, i, a.expr)
+			}
+			params = append(params, "a"+strconv.Itoa(i))
+		}
+
+		call := receiver + "." + c.method + "(" + strings.Join(params, ", ") + ")"
+		if c.reply {
+			p("return %s", call)
+		} else {
+			p("return nil, %s", call)
+		}
+		p("},")
+		p("},")
+	}
+	p("}")
+	p("}")
+
+	// The discord package registers the event, it's imported for it's side effects
+	// if the registrar's package does not use it.
+	for _, c := range commands {
+		if c.signature != signatureMessageCreate && c.signature != signatureContextMessageCreate {
+			continue
+		}
+
+		if _, ok := g.imports[discordPath]; !ok {
+			g.imports[discordPath] = "_"
+		}
+		break
+	}
+
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by routergen -type=%s; DO NOT EDIT.\n\n", typeName)
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkg.Name())
+	buf.WriteString("import (\n")
+	for _, path := range paths {
+		name := g.imports[path]
+		if name == filepath.Base(path) {
+			fmt.Fprintf(&buf, "%q\n", path)
+		} else {
+			fmt.Fprintf(&buf, "%s %q\n", name, path)
+		}
+	}
+	buf.WriteString(")\n\n")
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("routergen: failed to format generated code: %v", err)
+	}
+
+	return src, nil
+}
+
+// renderParse renders the body of an argument's parse function.
+func (g *generator) renderParse(p func(string, ...interface{}), routerName string, a *argument) {
+	switch a.parser {
+	case parserParseable:
+		p("v := new(%s)", a.elem)
+		p("return v, v.Parse(input)")
+	case parserManualParseable:
+		p("v := new(%s)", a.elem)
+		p("return v, v.ParseContent(input)")
+	case parserString:
+		if a.expr == "string" {
+			p("return input, nil")
+		} else {
+			p("return %s(input), nil", a.expr)
+		}
+	case parserInt:
+		p("v, err := %s.ParseInt(input, 10, 64)", g.use("strconv", "strconv"))
+		p("return %s, err", convert(a.expr, "int64"))
+	case parserUint:
+		p("v, err := %s.ParseUint(input, 10, 64)", g.use("strconv", "strconv"))
+		p("return %s, err", convert(a.expr, "uint64"))
+	case parserFloat:
+		p("v, err := %s.ParseFloat(input, 64)", g.use("strconv", "strconv"))
+		p("return %s, err", convert(a.expr, "float64"))
+	case parserBool:
+		p("v, err := %s.ParseBool(input)", routerName)
+		p("return %s, err", convert(a.expr, "bool"))
+	}
+}
+
+// convert returns the expression converting v to a type, v is not converted if it already has the type.
+func convert(expr string, from string) string {
+	if expr == from {
+		return "v"
+	}
+
+	return expr + "(v)"
+}
+
+// getReceiverName returns the receiver name used by the type's methods, defaulting to c.
+func getReceiverName(named *types.Named) string {
+	for i := 0; i < named.NumMethods(); i++ {
+		recv := named.Method(i).Type().(*types.Signature).Recv()
+		if recv != nil && recv.Name() != "" && recv.Name() != "_" {
+			return recv.Name()
+		}
+	}
+
+	return "c"
+}
@@ -0,0 +1,151 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router"
+	"go.matthewp.io/router/cmd/routergen/testdata/commands"
+	"go.matthewp.io/router/routertest"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	t.Run("Golden", func(t *testing.T) {
+		a := assert.New(t)
+
+		dir := filepath.Join("testdata", "commands")
+		output := filepath.Join(dir, "commands_routergen.go")
+
+		src, err := generate(dir, "Commands", output)
+		if !a.NoError(err) {
+			return
+		}
+
+		if *update {
+			a.NoError(ioutil.WriteFile(output, src, 0644))
+			return
+		}
+
+		expected, err := ioutil.ReadFile(output)
+		if !a.NoError(err) {
+			return
+		}
+
+		a.Equal(string(expected), string(src))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		a := assert.New(t)
+
+		dir := filepath.Join("testdata", "invalid")
+		_, err := generate(dir, "Commands", filepath.Join(dir, "commands_routergen.go"))
+		a.EqualError(err, "routergen: Commands.Echo's argument 0: unsupported type []string")
+
+		_, err = generate(dir, "Missing", filepath.Join(dir, "missing_routergen.go"))
+		a.Error(err)
+	})
+}
+
+// reflectedCommands hides the generated RouterCommands method so the router
+// registers the commands using reflection.
+type reflectedCommands struct {
+	*commands.Commands
+}
+
+func (c *reflectedCommands) RouterCommands() {}
+
+// TestGenerated runs the golden file through a router, so the generated code
+// and the router's handling of it cannot drift apart.
+func TestGenerated(t *testing.T) {
+	t.Run("Handle", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &commands.Commands{}
+		r, session := routertest.NewRouter(t, ".", registrar)
+
+		a.NoError(r.Handle(routertest.NewMessageCreate(".ping", nil)))
+		session.AssertSent(t, "Pong!")
+
+		a.NoError(r.Handle(routertest.NewMessageCreate(".ban <@1234> 7 being rude", nil)))
+		a.Equal("1234", registrar.User)
+		a.Equal(7, registrar.Days)
+		a.Equal("being rude", registrar.Reason)
+
+		// Omitted optional arguments receive an empty value instead of nil.
+		a.NoError(r.Handle(routertest.NewMessageCreate(".ban <@1234> 7", nil)))
+		a.Empty(registrar.Reason)
+
+		a.NoError(r.Handle(routertest.NewMessageCreate(".set 3 true 1.5", nil)))
+		a.Equal(commands.Level(3), registrar.Level)
+		a.True(registrar.Enabled)
+		a.Equal(1.5, registrar.Scale)
+
+		routertest.AssertError(t, r.Handle(routertest.NewMessageCreate(".set high true 1.5", nil)), &router.ErrInvalidUsage{})
+		routertest.AssertError(t, r.Handle(routertest.NewMessageCreate(".reply hello", nil)), &router.ErrUnknownCommand{})
+	})
+
+	t.Run("HandleMessage", func(t *testing.T) {
+		a := assert.New(t)
+
+		r, _ := routertest.NewRouter(t, ".", &commands.Commands{})
+
+		var replies []string
+		transport := router.TransportFunc(func(_ context.Context, _ router.Message, content string) error {
+			replies = append(replies, content)
+			return nil
+		})
+
+		// Commands that take a router.Message can be ran from any transport.
+		a.NoError(r.HandleMessage(context.Background(), transport, &testMessage{content: ".status"}))
+		a.Equal([]string{"Online"}, replies)
+
+		err := r.HandleMessage(context.Background(), transport, &testMessage{content: ".ping"})
+		routertest.AssertError(t, err, &router.ErrUnsupportedTransport{})
+	})
+
+	t.Run("Reflection", func(t *testing.T) {
+		a := assert.New(t)
+
+		generated, _ := routertest.NewRouter(t, ".", &commands.Commands{})
+		reflected, _ := routertest.NewRouter(t, ".", &reflectedCommands{Commands: &commands.Commands{}})
+
+		a.Len(generated.Manifest().Commands, 4)
+
+		// The generated commands must match the commands registered using reflection.
+		a.Equal(reflected.Manifest(), generated.Manifest())
+		a.Equal(reflected.ApplicationCommands(), generated.ApplicationCommands())
+	})
+}
+
+// testMessage represents a message received from a transport other than Discord.
+type testMessage struct {
+	content string
+}
+
+func (m *testMessage) Content() string {
+	return m.content
+}
+
+func (m *testMessage) AuthorID() string {
+	return "alice"
+}
+
+func (m *testMessage) ChannelID() string {
+	return "#general"
+}
+
+func (m *testMessage) GuildID() string {
+	return ""
+}
@@ -0,0 +1,64 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+// Command routergen generates type-safe dispatch and parsing code for a command
+// registrar, allowing the router to run the registrar's commands without reflection.
+//
+// Usage:
+//
+//	//go:generate routergen -type=commands
+//
+// The generated file implements router.GeneratedRegistrar on the registrar's
+// pointer type. Invalid command signatures and unsupported argument types are
+// reported when the code is generated, and the generated code fails to compile
+// if the registrar's methods change without it being regenerated.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeName := flag.String("type", "", "name of the registrar type, required")
+	output := flag.String("output", "", "output file name, defaults to <type>_routergen.go")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: routergen -type=T [-output=file] [directory]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	if *output == "" {
+		*output = strings.ToLower(*typeName) + "_routergen.go"
+	}
+	*output = filepath.Join(dir, filepath.Base(*output))
+
+	src, err := generate(dir, *typeName, *output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,81 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+// Package commands is used to test the output of routergen.
+package commands
+
+import (
+	"context"
+	"github.com/andersfylling/disgord"
+	"go.matthewp.io/router"
+	"go.matthewp.io/router/args"
+)
+
+//go:generate go run go.matthewp.io/router/cmd/routergen -type=Commands
+
+// Level represents a named argument type.
+type Level uint8
+
+// Commands represents a registrar used to test the output of routergen, the
+// fields are set to the arguments of the last command that was ran.
+type Commands struct {
+	User    string
+	Days    int
+	Reason  string
+	Level   Level
+	Enabled bool
+	Scale   float64
+}
+
+// Ban bans a user.
+func (c *Commands) Ban(ctx *router.Context, user *args.UserMention, days int, reason *args.RawArguments) error {
+	c.User, c.Days, c.Reason = string(*user), days, reason.String()
+	return nil
+}
+
+// Ping replies with pong.
+func (c *Commands) Ping(_ *disgord.MessageCreate) (string, error) {
+	return "Pong!", nil
+}
+
+// Set sets a level.
+func (c *Commands) Set(_ context.Context, _ *disgord.MessageCreate, level Level, enabled bool, scale float64) error {
+	c.Level, c.Enabled, c.Scale = level, enabled, scale
+	return nil
+}
+
+// Status replies with the bot's status, it can be ran from any transport.
+func (c *Commands) Status(_ context.Context, _ router.Message) (string, error) {
+	return "Online", nil
+}
+
+// Reply is not a command because it does not take an event or a context.
+func (c *Commands) Reply(content string) error {
+	return nil
+}
+
+// Descriptions .
+func (c *Commands) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+// Arguments .
+func (c *Commands) Arguments() map[string][]string {
+	return map[string][]string{
+		"ban": {"user", "days", "reason"},
+		"set": {"level", "enabled", "scale"},
+	}
+}
+
+// Metadata .
+func (c *Commands) Metadata() map[string]router.Metadata {
+	return map[string]router.Metadata{
+		"ban": {
+			Optional: []string{"reason"},
+		},
+	}
+}
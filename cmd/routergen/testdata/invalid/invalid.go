@@ -0,0 +1,37 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+// Package invalid is used to test the errors reported by routergen.
+package invalid
+
+import (
+	"github.com/andersfylling/disgord"
+	"go.matthewp.io/router"
+)
+
+// Commands represents a registrar with an invalid command.
+type Commands struct{}
+
+// Ping does not return an error.
+func (c *Commands) Ping(_ *disgord.MessageCreate) string {
+	return "Pong!"
+}
+
+// Echo takes an unsupported argument.
+func (c *Commands) Echo(_ *router.Context, _ []string) error {
+	return nil
+}
+
+// Descriptions .
+func (c *Commands) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+// Arguments .
+func (c *Commands) Arguments() map[string][]string {
+	return map[string][]string{}
+}
@@ -8,20 +8,330 @@
 package router
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 )
 
+// signature represents the leading parameters of a command method, before the command's arguments.
+type signature int
+
+const (
+	// signatureEvent is a method that takes a platform's event, such as a *disgord.MessageCreate.
+	signatureEvent signature = iota
+	// signatureContextEvent is a method that takes a context.Context and a platform's event.
+	signatureContextEvent
+	// signatureContext is a method that takes a *router.Context.
+	signatureContext
+	// signatureGenerated is a method called by code generated by routergen.
+	signatureGenerated
+	// signatureMessage is a method that takes a router.Message.
+	signatureMessage
+	// signatureContextMessage is a method that takes a context.Context and a router.Message.
+	signatureContextMessage
+)
+
+// Command represents a registered command.
 type Command struct {
 	name        string
 	Description string
 
+	// module is the name of the module the command was registered by, empty if
+	// the command was not registered by a module.
+	module string
+
+	category        string
+	longDescription string
+	aliases         []string
+	examples        []string
+	hidden          bool
+	deprecated      string
+
 	value  reflect.Value
 	method reflect.Method
 
-	arguments []argumentValueFn
-	usage     string
+	// signature is the type of the method's leading parameters.
+	signature signature
+	// platform is the platform of the event the method takes, nil if the method
+	// does not take an event.
+	platform *Platform
+
+	// generated calls the method when the signature is signatureGenerated.
+	generated func(ctx *Context, values []interface{}) (interface{}, error)
+
+	middleware []Middleware
+
+	permissions    uint64
+	botPermissions uint64
+	restrictions   Restrictions
+	cooldown       *Cooldown
+	concurrency    []Concurrency
+	timeout        time.Duration
+
+	arguments    []argumentValueFn
+	argumentInfo []*Argument
+	usage        string
+
+	// choices maps an argument's name to the choices of it's option.
+	choices map[string][]*Choice
 
 	rawArgumentsIndex int
+
+	// optionalArguments is the number of trailing arguments that may be omitted.
+	optionalArguments int
+}
+
+// newCommand returns a new command that calls value, a function with the given signature.
+func newCommand(name string, value reflect.Value, sig signature) *Command {
+	return &Command{
+		name: name,
+
+		value: value,
+
+		signature: sig,
+
+		rawArgumentsIndex: -1,
+	}
+}
+
+// setMetadata sets the command's fields from it's metadata, an error is returned
+// if the metadata is invalid.
+func (c *Command) setMetadata(metadata Metadata) error {
+	for _, concurrency := range metadata.Concurrency {
+		if concurrency.Limit < 1 {
+			return fmt.Errorf("router: %s's concurrency limit must be at least 1", c.name)
+		}
+	}
+
+	c.category = metadata.Category
+	c.longDescription = metadata.LongDescription
+	c.examples = metadata.Examples
+	c.hidden = metadata.Hidden
+	c.deprecated = metadata.Deprecated
+	c.middleware = metadata.Middleware
+	c.permissions = metadata.Permissions
+	c.botPermissions = metadata.BotPermissions
+	c.restrictions = metadata.Restrictions
+	c.cooldown = metadata.Cooldown
+	c.concurrency = metadata.Concurrency
+	c.timeout = metadata.Timeout
+	c.choices = metadata.Choices
+	for _, alias := range metadata.Aliases {
+		c.aliases = append(c.aliases, strings.ToLower(alias))
+	}
+
+	return nil
+}
+
+// setArguments sets the command's arguments from the parameters of t starting at offset,
+// names are the names of the arguments and name is the function's name used in errors.
+func (c *Command) setArguments(name string, t reflect.Type, offset int, names []string, descriptions map[string]string) error {
+	args := t.NumIn()
+	if args-offset != len(names) {
+		return fmt.Errorf("router: %s's usage does not have all the arguments present", name)
+	}
+
+	c.arguments = make([]argumentValueFn, 0, args-offset)
+
+	var usageBuilder strings.Builder
+
+	for i := offset; i < args; i++ {
+		t := t.In(i)
+
+		if t.Implements(typeIManualParseable) {
+			c.rawArgumentsIndex = i - offset
+		}
+
+		argValue, err := getArgumentValueFn(t)
+		if err != nil {
+			return fmt.Errorf("router: error parsing argument %s: %v", t.String(), err)
+		}
+
+		var usage string
+		if t.Implements(typeIFormatter) {
+			mt, ok := t.MethodByName("Format")
+			if !ok {
+				panic("router: type IFormatter does not implement Format")
+			}
+
+			v := reflect.New(t.Elem())
+
+			ret := mt.Func.Call([]reflect.Value{
+				v, reflect.ValueOf(names[i-offset]),
+			})
+
+			usage = ret[0].Interface().(string)
+		} else {
+			usage = "<" + names[i-offset] + ": " + t.String() + ">"
+		}
+
+		c.arguments = append(c.arguments, argValue)
+		c.argumentInfo = append(c.argumentInfo, &Argument{
+			name:        names[i-offset],
+			Description: descriptions[names[i-offset]],
+			typ:         t.String(),
+			usage:       usage,
+			kind:        getArgumentKind(t),
+			option:      getOptionType(t),
+		})
+		usageBuilder.WriteString(" " + usage)
+	}
+
+	c.usage = usageBuilder.String()
+	return nil
+}
+
+// setOptional marks the arguments named by optional as optional, every optional
+// argument must come after the command's required arguments. name is the
+// function's name used in errors.
+func (c *Command) setOptional(name string, optional []string) error {
+	if len(optional) < 1 {
+		return nil
+	}
+
+	found := make(map[string]bool, len(optional))
+	for _, argument := range optional {
+		found[argument] = false
+	}
+
+	var usageBuilder strings.Builder
+	for _, argument := range c.argumentInfo {
+		if _, ok := found[argument.name]; ok {
+			found[argument.name] = true
+
+			argument.optional = true
+			argument.usage = getOptionalUsage(argument.usage)
+			c.optionalArguments++
+		} else if c.optionalArguments > 0 {
+			return fmt.Errorf("router: %s's required argument %s comes after an optional argument", name, argument.name)
+		}
+
+		usageBuilder.WriteString(" " + argument.usage)
+	}
+
+	for _, argument := range optional {
+		if !found[argument] {
+			return fmt.Errorf("router: %s does not have an argument named %s", name, argument)
+		}
+	}
+
+	c.usage = usageBuilder.String()
+	return nil
+}
+
+// setDefaults sets the defaults of the command's optional arguments, every
+// default must be valid input for it's argument. name is the function's name
+// used in errors.
+func (c *Command) setDefaults(name string, defaults map[string]string) error {
+	found := 0
+	for i, argument := range c.argumentInfo {
+		def, ok := defaults[argument.name]
+		if !ok {
+			continue
+		}
+		found++
+
+		if !argument.optional {
+			return fmt.Errorf("router: %s's argument %s has a default but is not optional", name, argument.name)
+		}
+
+		if _, err := c.arguments[i](def); err != nil {
+			return fmt.Errorf("router: %s's default for %s is invalid: %v", name, argument.name, err)
+		}
+
+		argument.def = def
+	}
+
+	if found < len(defaults) {
+		for argument := range defaults {
+			if c.argumentIndex(argument) < 0 {
+				return fmt.Errorf("router: %s does not have an argument named %s", name, argument)
+			}
+		}
+	}
+
+	return nil
+}
+
+// argumentIndex returns the index of the argument with the name, -1 if the
+// command does not have the argument.
+func (c *Command) argumentIndex(name string) int {
+	for i, argument := range c.argumentInfo {
+		if argument.name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// getOptionalUsage returns the usage of an optional argument, angle brackets
+// are replaced with square brackets, e.g. "<reason: string>" becomes "[reason: string]".
+func getOptionalUsage(usage string) string {
+	if strings.HasPrefix(usage, "<") && strings.HasSuffix(usage, ">") {
+		return "[" + usage[1:len(usage)-1] + "]"
+	}
+
+	return usage
+}
+
+// Argument represents a command's argument.
+type Argument struct {
+	name        string
+	Description string
+	typ         string
+	usage       string
+	optional    bool
+	// def is the input parsed when the optional argument is omitted, empty if
+	// the argument does not have a default.
+	def string
+
+	// kind is the kind of value the argument is parsed from, option is the type
+	// of it's option set by OptionTyper.
+	kind   reflect.Kind
+	option int
+}
+
+// Name returns the argument's name.
+func (a *Argument) Name() string {
+	return a.name
+}
+
+// Type returns the name of the argument's Go type.
+func (a *Argument) Type() string {
+	return a.typ
+}
+
+// Usage returns the argument's usage.
+func (a *Argument) Usage() string {
+	return a.usage
+}
+
+// Kind returns the kind of value the argument is parsed from, parseable
+// arguments are parsed from a reflect.String.
+func (a *Argument) Kind() reflect.Kind {
+	return a.kind
+}
+
+// OptionType returns the type of the argument's option on platforms with
+// structured commands, zero is returned if the argument's type does not
+// implement OptionTyper.
+func (a *Argument) OptionType() int {
+	return a.option
+}
+
+// Optional returns true if the argument may be omitted, omitted arguments
+// receive their default or their type's zero value, pointers to parseable types
+// receive an empty value.
+func (a *Argument) Optional() bool {
+	return a.optional
+}
+
+// Default returns the input parsed when the optional argument is omitted, an
+// empty string is returned if the argument does not have a default.
+func (a *Argument) Default() string {
+	return a.def
 }
 
 // Name returns the command's name.
@@ -34,16 +344,138 @@ func (c *Command) Usage() string {
 	return c.usage
 }
 
+// Category returns the command's category.
+func (c *Command) Category() string {
+	return c.category
+}
+
+// Module returns the name of the module the command was registered by, the
+// name is empty if the command was not registered by a module.
+func (c *Command) Module() string {
+	return c.module
+}
+
+// LongDescription returns the command's long description, falling back to
+// the command's description if it does not have one.
+func (c *Command) LongDescription() string {
+	if c.longDescription == "" {
+		return c.Description
+	}
+
+	return c.longDescription
+}
+
+// Aliases returns the command's aliases.
+func (c *Command) Aliases() []string {
+	return c.aliases
+}
+
+// Examples returns the command's example invocations.
+func (c *Command) Examples() []string {
+	return c.examples
+}
+
+// Hidden returns true if the command should not be shown in the help message.
+func (c *Command) Hidden() bool {
+	return c.hidden
+}
+
+// Deprecated returns the command's deprecation notice, an empty string is
+// returned if the command is not deprecated.
+func (c *Command) Deprecated() string {
+	return c.deprecated
+}
+
+// IsDeprecated returns true if the command is deprecated.
+func (c *Command) IsDeprecated() bool {
+	return c.deprecated != ""
+}
+
+// Permissions returns the permission bits the user requires to run the command.
+func (c *Command) Permissions() uint64 {
+	return c.permissions
+}
+
+// BotPermissions returns the permission bits the bot requires to run the command.
+func (c *Command) BotPermissions() uint64 {
+	return c.botPermissions
+}
+
+// Restrictions returns where and by who the command can be used.
+func (c *Command) Restrictions() Restrictions {
+	return c.restrictions
+}
+
+// Cooldown returns the command's cooldown, nil is returned if the command does not have a cooldown.
+func (c *Command) Cooldown() *Cooldown {
+	return c.cooldown
+}
+
+// Concurrency returns the command's concurrency limits.
+func (c *Command) Concurrency() []Concurrency {
+	return c.concurrency
+}
+
+// Timeout returns the command's timeout, zero means the router's default timeout is used.
+func (c *Command) Timeout() time.Duration {
+	return c.timeout
+}
+
+// Arguments returns the command's arguments.
+func (c *Command) Arguments() []*Argument {
+	return c.argumentInfo
+}
+
+// Choices returns the choices of the command's arguments, mapped by the argument's name.
+func (c *Command) Choices() map[string][]*Choice {
+	return c.choices
+}
+
+// Platform returns the platform of the event the command takes, nil is returned
+// if the command does not take an event.
+func (c *Command) Platform() *Platform {
+	return c.platform
+}
+
+// omitted returns the value passed to the command when the argument at index i
+// is omitted, the argument's default is parsed if it has one.
+func (c *Command) omitted(i int) (reflect.Value, error) {
+	if def := c.argumentInfo[i].def; def != "" {
+		return c.arguments[i](def)
+	}
+
+	return c.zero(i), nil
+}
+
+// zero returns the value passed to the command when the argument at index i is
+// omitted and has no default, pointers to parseable types are allocated so their methods can be
+// called. Generated commands receive nil and convert it themselves.
+func (c *Command) zero(i int) reflect.Value {
+	if c.signature == signatureGenerated {
+		return nilV
+	}
+
+	// The command's arguments are the method's trailing parameters.
+	t := c.value.Type()
+	t = t.In(t.NumIn() - len(c.arguments) + i)
+	if t.Kind() == reflect.Ptr && (t.Implements(typeIParseable) || t.Implements(typeIManualParseable)) {
+		return reflect.New(t.Elem())
+	}
+
+	return reflect.Zero(t)
+}
+
 func (c *Command) isValidArgumentLength(length int) bool {
 	// The Raw Arguments Index allows us to receive multiple spaced arguments as
 	// one argument,  meaning that you cannot just directly check if the length
 	// of arguments from the command and the length of the message's arguments match.
 	// c.rawArgumentsIndex == -1 means there are no raw arguments in the command signature.
-	if c.rawArgumentsIndex == -1 {
-		if length != len(c.arguments) {
-			return false
-		}
-	} else if length < len(c.arguments) {
+	// Optional arguments may be omitted, lowering the minimum length.
+	if length < len(c.arguments)-c.optionalArguments {
+		return false
+	}
+
+	if c.rawArgumentsIndex == -1 && length > len(c.arguments) {
 		return false
 	}
 
@@ -8,6 +8,7 @@
 package router
 
 import (
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -17,3 +18,98 @@ func TestCommand_isValidArgumentLength(t *testing.T) {
 	// a test for this, but I will deal with that at a later date, for the mean
 	// time this comment will leave me with motivation to write tests!!!
 }
+
+type optionalCommands struct {
+	optional []string
+	defaults map[string]string
+
+	sides int
+}
+
+func (c *optionalCommands) Roll(_ *testEvent, sides int, _ string) error {
+	c.sides = sides
+	return nil
+}
+
+func (c *optionalCommands) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *optionalCommands) Arguments() map[string][]string {
+	return map[string][]string{
+		"roll": {"sides", "label"},
+	}
+}
+
+func (c *optionalCommands) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"roll": {
+			Optional: c.optional,
+			Defaults: c.defaults,
+		},
+	}
+}
+
+func TestCommand_setOptional(t *testing.T) {
+	t.Run("Optional", func(t *testing.T) {
+		a := assert.New(t)
+
+		router, err := New(prefix, &optionalCommands{optional: []string{"sides", "label"}})
+		if !a.NoError(err) {
+			return
+		}
+
+		command := router.GetCommandByName("roll")
+		a.Equal(" [sides: int] [label: string]", command.Usage())
+		a.True(command.Arguments()[0].Optional())
+		a.True(command.isValidArgumentLength(0))
+		a.False(command.isValidArgumentLength(3))
+	})
+
+	t.Run("RequiredAfterOptional", func(t *testing.T) {
+		_, err := New(prefix, &optionalCommands{optional: []string{"sides"}})
+		assert.EqualError(t, err, "router: Roll's required argument label comes after an optional argument")
+	})
+
+	t.Run("UnknownArgument", func(t *testing.T) {
+		_, err := New(prefix, &optionalCommands{optional: []string{"label", "count"}})
+		assert.EqualError(t, err, "router: Roll does not have an argument named count")
+	})
+}
+
+func TestCommand_setDefaults(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &optionalCommands{optional: []string{"sides", "label"}, defaults: map[string]string{"sides": "6"}}
+		router, err := New(prefix, registrar)
+		if !a.NoError(err) {
+			return
+		}
+
+		a.Equal("6", router.GetCommandByName("roll").Arguments()[0].Default())
+
+		a.NoError(handle(router, newTestEvent(prefix+"roll")))
+		a.Equal(6, registrar.sides)
+
+		a.NoError(handle(router, newTestEvent(prefix+"roll 20")))
+		a.Equal(20, registrar.sides)
+	})
+
+	t.Run("NotOptional", func(t *testing.T) {
+		_, err := New(prefix, &optionalCommands{defaults: map[string]string{"sides": "6"}})
+		assert.EqualError(t, err, "router: Roll's argument sides has a default but is not optional")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := New(prefix, &optionalCommands{optional: []string{"sides", "label"}, defaults: map[string]string{"sides": "six"}})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "router: Roll's default for sides is invalid")
+		}
+	})
+
+	t.Run("UnknownArgument", func(t *testing.T) {
+		_, err := New(prefix, &optionalCommands{optional: []string{"label"}, defaults: map[string]string{"count": "1"}})
+		assert.EqualError(t, err, "router: Roll does not have an argument named count")
+	})
+}
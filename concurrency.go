@@ -0,0 +1,190 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// ConcurrencyPolicy represents what happens when a command's concurrency limit is reached.
+type ConcurrencyPolicy int
+
+const (
+	// ConcurrencyReject rejects the new execution with an *ErrConcurrencyLimit.
+	ConcurrencyReject ConcurrencyPolicy = iota
+	// ConcurrencyQueue waits for a running execution to finish.
+	ConcurrencyQueue
+	// ConcurrencyCancel cancels the oldest running execution's context and
+	// waits for it to finish.
+	ConcurrencyCancel
+)
+
+// Concurrency represents a limit on the amount of in-flight executions of a command.
+type Concurrency struct {
+	// Limit is the maximum amount of in-flight executions, it must be at least 1.
+	Limit int
+	// Scope is what the limit is keyed by.
+	Scope BucketScope
+	// Policy is what happens when the limit is reached.
+	Policy ConcurrencyPolicy
+}
+
+// concurrencyLimiter tracks the in-flight executions of commands.
+type concurrencyLimiter struct {
+	mx    sync.Mutex
+	slots map[string]*concurrencySlot
+}
+
+// concurrencySlot represents the in-flight executions for a single key.
+type concurrencySlot struct {
+	runs []*concurrencyRun
+
+	// released is closed and replaced whenever a run is released to wake up any waiters.
+	released chan struct{}
+}
+
+// concurrencyRun represents a single in-flight execution.
+type concurrencyRun struct {
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+func newConcurrencyLimiter() *concurrencyLimiter {
+	return &concurrencyLimiter{
+		slots: make(map[string]*concurrencySlot),
+	}
+}
+
+// acquire acquires a slot for every one of the command's concurrency limits, the returned
+// function must be called to release the slots once the execution has finished.
+func (l *concurrencyLimiter) acquire(ctx context.Context, cancel context.CancelFunc, m Message, command *Command) (func(), error) {
+	releases := make([]func(), 0, len(command.concurrency))
+	release := func() {
+		for _, fn := range releases {
+			fn()
+		}
+	}
+
+	for i, concurrency := range command.concurrency {
+		key := getBucketKey(m, command.name+":"+strconv.Itoa(i), concurrency.Scope)
+
+		fn, err := l.acquireKey(ctx, cancel, key, concurrency)
+		if err != nil {
+			release()
+
+			if err == errConcurrencyLimit {
+				return nil, &ErrConcurrencyLimit{
+					Command: command.name,
+					Limit:   concurrency.Limit,
+					Scope:   concurrency.Scope,
+				}
+			}
+
+			return nil, err
+		}
+
+		releases = append(releases, fn)
+	}
+
+	return release, nil
+}
+
+// errConcurrencyLimit is returned by acquireKey when the limit is reached and the policy is ConcurrencyReject.
+var errConcurrencyLimit = errors.New("router: concurrency limit reached")
+
+// acquireKey acquires a slot for a single key.
+func (l *concurrencyLimiter) acquireKey(ctx context.Context, cancel context.CancelFunc, key string, concurrency Concurrency) (func(), error) {
+	run := &concurrencyRun{
+		cancel: cancel,
+	}
+
+	for {
+		l.mx.Lock()
+
+		slot, ok := l.slots[key]
+		if !ok {
+			slot = &concurrencySlot{
+				released: make(chan struct{}),
+			}
+			l.slots[key] = slot
+		}
+
+		if len(slot.runs) < concurrency.Limit {
+			slot.runs = append(slot.runs, run)
+			l.mx.Unlock()
+
+			return func() {
+				l.release(key, run)
+			}, nil
+		}
+
+		switch concurrency.Policy {
+		case ConcurrencyReject:
+			l.mx.Unlock()
+			return nil, errConcurrencyLimit
+
+		case ConcurrencyCancel:
+			// Cancel the oldest runs until there is room for this one once they finish.
+			active := 0
+			for _, r := range slot.runs {
+				if !r.cancelled {
+					active++
+				}
+			}
+
+			for _, r := range slot.runs {
+				if active < concurrency.Limit {
+					break
+				}
+
+				if !r.cancelled {
+					r.cancelled = true
+					r.cancel()
+					active--
+				}
+			}
+		}
+
+		released := slot.released
+		l.mx.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// release releases a run's slot and wakes up any waiters.
+func (l *concurrencyLimiter) release(key string, run *concurrencyRun) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	slot, ok := l.slots[key]
+	if !ok {
+		return
+	}
+
+	for i, r := range slot.runs {
+		if r == run {
+			slot.runs = append(slot.runs[:i], slot.runs[i+1:]...)
+			break
+		}
+	}
+
+	close(slot.released)
+	slot.released = make(chan struct{})
+
+	if len(slot.runs) == 0 {
+		delete(l.slots, key)
+	}
+}
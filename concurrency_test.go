@@ -0,0 +1,207 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type concurrencyRegistrar struct {
+	started chan struct{}
+	finish  chan struct{}
+}
+
+func newConcurrencyRegistrar() *concurrencyRegistrar {
+	return &concurrencyRegistrar{
+		started: make(chan struct{}, 2),
+		finish:  make(chan struct{}),
+	}
+}
+
+func (c *concurrencyRegistrar) Backup(_ *testEvent) error {
+	c.started <- struct{}{}
+	<-c.finish
+	return nil
+}
+
+func (c *concurrencyRegistrar) Queue(_ *testEvent) error {
+	c.started <- struct{}{}
+	<-c.finish
+	return nil
+}
+
+func (c *concurrencyRegistrar) Cancel(e *testEvent) error {
+	c.started <- struct{}{}
+	<-e.Ctx.Done()
+	return e.Ctx.Err()
+}
+
+func (c *concurrencyRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *concurrencyRegistrar) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+func (c *concurrencyRegistrar) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"backup": {
+			Concurrency: []Concurrency{
+				{Limit: 1, Scope: BucketGuild, Policy: ConcurrencyReject},
+			},
+		},
+		"queue": {
+			Concurrency: []Concurrency{
+				{Limit: 1, Scope: BucketGlobal, Policy: ConcurrencyQueue},
+			},
+		},
+		"cancel": {
+			Concurrency: []Concurrency{
+				{Limit: 1, Scope: BucketUser, Policy: ConcurrencyCancel},
+			},
+		},
+	}
+}
+
+func TestRouter_Handle_Concurrency(t *testing.T) {
+	t.Run("InvalidLimit", func(t *testing.T) {
+		_, err := NewCommand("backup").
+			Metadata(Metadata{Concurrency: []Concurrency{{Limit: 0, Scope: BucketGuild}}}).
+			Handler(func(_ *testEvent) error {
+				return nil
+			}).
+			Build()
+		assert.EqualError(t, err, "router: backup's concurrency limit must be at least 1")
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := newConcurrencyRegistrar()
+		router, err := New(prefix, registrar)
+		if !a.NoError(err) {
+			return
+		}
+
+		done := make(chan error)
+		go func() {
+			done <- handle(router, newRestrictedMessage(prefix+"backup", "1", "5", "0"))
+		}()
+		<-registrar.started
+
+		err = handle(router, newRestrictedMessage(prefix+"backup", "2", "5", "0"))
+		a.Equal(&ErrConcurrencyLimit{Command: "backup", Limit: 1, Scope: BucketGuild}, err)
+
+		close(registrar.finish)
+		a.NoError(<-done)
+
+		// Different guilds do not share the limit.
+		a.NoError(handle(router, newRestrictedMessage(prefix+"backup", "2", "6", "0")))
+	})
+
+	t.Run("DM", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := newConcurrencyRegistrar()
+		router, err := New(prefix, registrar)
+		if !a.NoError(err) {
+			return
+		}
+
+		done := make(chan error, 2)
+		go func() {
+			done <- handle(router, newRestrictedMessage(prefix+"backup", "1", "", "7"))
+		}()
+		<-registrar.started
+
+		// Direct messages do not share a guild scoped limit.
+		go func() {
+			done <- handle(router, newRestrictedMessage(prefix+"backup", "2", "", "8"))
+		}()
+		select {
+		case <-registrar.started:
+		case err := <-done:
+			a.Fail("backup was not ran", "%v", err)
+			done <- nil
+		}
+
+		close(registrar.finish)
+		a.NoError(<-done)
+		a.NoError(<-done)
+	})
+
+	t.Run("Queue", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := newConcurrencyRegistrar()
+		router, err := New(prefix, registrar)
+		if !a.NoError(err) {
+			return
+		}
+
+		done := make(chan error, 2)
+		go func() {
+			done <- handle(router, newRestrictedMessage(prefix+"queue", "1", "", "0"))
+		}()
+		<-registrar.started
+
+		go func() {
+			done <- handle(router, newRestrictedMessage(prefix+"queue", "2", "", "0"))
+		}()
+
+		select {
+		case <-registrar.started:
+			a.Fail("queued execution started before the first execution finished")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(registrar.finish)
+		a.NoError(<-done)
+		a.NoError(<-done)
+		a.Len(registrar.started, 1)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := newConcurrencyRegistrar()
+		router, err := New(prefix, registrar)
+		if !a.NoError(err) {
+			return
+		}
+
+		done := make(chan error)
+		go func() {
+			done <- handle(router, newRestrictedMessage(prefix+"cancel", "1", "", "0"))
+		}()
+		<-registrar.started
+
+		go func() {
+			done <- handle(router, newRestrictedMessage(prefix+"cancel", "1", "", "0"))
+		}()
+
+		// The first execution is cancelled.
+		err = <-done
+		if a.IsType(&ErrCommandExecution{}, err) {
+			a.Contains(err.Error(), "context canceled")
+		}
+		<-registrar.started
+
+		router.concurrency.mx.Lock()
+		for _, slot := range router.concurrency.slots {
+			for _, run := range slot.runs {
+				run.cancel()
+			}
+		}
+		router.concurrency.mx.Unlock()
+		a.Error(<-done)
+	})
+}
@@ -0,0 +1,166 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"time"
+)
+
+// WithTimeout sets the default timeout for the context of every command,
+// a command's own timeout takes precedence. Contexts are also cancelled once
+// the router is shut down, see Shutdown.
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Router) {
+		r.timeout = timeout
+	}
+}
+
+// begin tracks a call to HandleMessage so Shutdown can wait for it, ErrRouterClosed is
+// returned if the router has been shut down. The returned function must be called
+// once the event has been handled.
+func (r *Router) begin() (func(), error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if r.closed {
+		return nil, ErrRouterClosed
+	}
+
+	r.wg.Add(1)
+	return r.wg.Done, nil
+}
+
+// startExecution creates the context for a command's execution and tracks it
+// so it can be cancelled when the router is shut down, the context is cancelled
+// immediately if the router is already shutting down. The returned function
+// must be called once the execution has finished.
+func (r *Router) startExecution(parent context.Context, command *Command) (context.Context, context.CancelFunc, func(), error) {
+	timeout := r.timeout
+	if command.timeout > 0 {
+		timeout = command.timeout
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(parent, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(parent)
+	}
+
+	r.mx.Lock()
+	// Executions that have not started yet are not run once Shutdown has given up waiting.
+	if r.aborted {
+		r.mx.Unlock()
+		cancel()
+		return nil, nil, nil, ErrRouterClosed
+	}
+
+	if r.closed {
+		cancel()
+	}
+
+	id := r.nextID
+	r.nextID++
+	r.inflight[id] = cancel
+	r.mx.Unlock()
+
+	finish := func() {
+		cancel()
+
+		r.mx.Lock()
+		delete(r.inflight, id)
+		r.mx.Unlock()
+	}
+
+	return ctx, cancel, finish, nil
+}
+
+// Shutdown stops the router from handling any new commands, cancels the context
+// of every in-flight and queued command and waits for them to return before
+// tearing down the router's modules. If ctx is done before the commands return,
+// any queued commands that have not started are discarded and ctx's error is returned.
+//
+// Shutdown can be called again, such as to retry after ctx was done, it waits for
+// the same commands and the modules are only torn down once.
+func (r *Router) Shutdown(ctx context.Context) error {
+	r.mx.Lock()
+	r.closed = true
+	for _, cancel := range r.inflight {
+		cancel()
+	}
+	if r.drained == nil {
+		r.drained = make(chan struct{})
+		go r.drain(r.drained)
+	}
+	drained := r.drained
+	r.mx.Unlock()
+
+	select {
+	case <-drained:
+		r.teardown.Do(func() {
+			r.teardownErr = r.teardownModules()
+		})
+		return r.teardownErr
+	case <-ctx.Done():
+		r.mx.Lock()
+		r.aborted = true
+		r.mx.Unlock()
+
+		return ctx.Err()
+	}
+}
+
+// drain waits for every call to HandleMessage and queued command to finish, stops
+// the worker pool and closes done.
+func (r *Router) drain(done chan struct{}) {
+	r.wg.Wait()
+
+	// Nothing can be dispatched to the pool once every call to HandleMessage has returned.
+	if r.pool != nil {
+		r.pool.close()
+	}
+	close(done)
+}
+
+// Context represents the context of a command's execution, it can be received
+// by a command instead of a Message or a platform's event.
+//
+// Context implements context.Context using the execution's context and Message
+// using the message that invoked the command.
+type Context struct {
+	context.Context
+	Message
+
+	// Transport is the transport the message was received by.
+	Transport Transport
+	Router    *Router
+	Command   *Command
+
+	// Prefix is the prefix the command was invoked with.
+	Prefix string
+	// RawArguments is the unparsed argument string following the command's name.
+	RawArguments string
+}
+
+// Reply sends a reply to the channel the command was invoked in, data is sent
+// by the transport if it implements ReplyTransport, otherwise data can only
+// contain strings and fmt.Stringers which are joined by spaces.
+func (c *Context) Reply(data ...interface{}) error {
+	return sendReply(c, c.Transport, c.Message, data...)
+}
+
+// Event returns the platform event of the message that invoked the command, with
+// it's context replaced by the execution's context, such as a *disgord.MessageCreate
+// for messages received by the discord package. nil is returned if the message
+// does not have an event.
+func (c *Context) Event() interface{} {
+	event, _ := getEvent(c.Context, c.Message)
+	return event
+}
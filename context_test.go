@@ -0,0 +1,141 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router/args"
+	"testing"
+	"time"
+)
+
+type contextRegistrar struct {
+	started  chan struct{}
+	deadline time.Time
+	sum      int
+	ctx      *Context
+}
+
+func (c *contextRegistrar) Say(ctx *Context, times int, _ *args.RawArguments) error {
+	c.ctx = ctx
+	return nil
+}
+
+func (c *contextRegistrar) Deadline(ctx context.Context, e *testEvent) error {
+	c.deadline, _ = ctx.Deadline()
+	if e.Ctx != ctx {
+		return context.Canceled
+	}
+
+	return nil
+}
+
+func (c *contextRegistrar) Add(_ context.Context, _ *testEvent, a int, b int) error {
+	c.sum = a + b
+	return nil
+}
+
+func (c *contextRegistrar) Wait(ctx context.Context, _ *testEvent) error {
+	c.started <- struct{}{}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (c *contextRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *contextRegistrar) Arguments() map[string][]string {
+	return map[string][]string{
+		"add": {"a", "b"},
+		"say": {"times", "message"},
+	}
+}
+
+func (c *contextRegistrar) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"deadline": {
+			Timeout: time.Hour,
+		},
+	}
+}
+
+func TestRouter_Handle_Context(t *testing.T) {
+	t.Run("Arguments", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &contextRegistrar{}
+		router, err := New(prefix, registrar)
+		if !a.NoError(err) {
+			return
+		}
+
+		a.NoError(handle(router, newTestEvent(prefix+"add 1 2")))
+		a.Equal(3, registrar.sum)
+	})
+
+	t.Run("RouterContext", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &contextRegistrar{}
+		router, err := New(prefix, registrar)
+		if !a.NoError(err) {
+			return
+		}
+
+		e := newTestEvent(prefix + "say 2 hello world")
+		e.channel = "10"
+		a.NoError(handle(router, e))
+
+		ctx := registrar.ctx
+		if a.NotNil(ctx) {
+			a.Equal("say", ctx.Command.Name())
+			a.Equal(prefix, ctx.Prefix)
+			a.Equal("2 hello world", ctx.RawArguments)
+			a.Equal("10", ctx.ChannelID())
+			a.Equal(ctx.Context, ctx.Event().(*testEvent).Ctx)
+			a.Error(ctx.Err(), "context was not cancelled after the command returned")
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &contextRegistrar{}
+		router, err := New(prefix, registrar, WithTimeout(time.Minute))
+		if !a.NoError(err) {
+			return
+		}
+
+		// The command's timeout takes precedence over the router's.
+		a.NoError(handle(router, newTestEvent(prefix+"deadline")))
+		a.WithinDuration(time.Now().Add(time.Hour), registrar.deadline, time.Minute)
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &contextRegistrar{started: make(chan struct{})}
+		router, err := New(prefix, registrar)
+		if !a.NoError(err) {
+			return
+		}
+
+		done := make(chan error)
+		go func() {
+			done <- handle(router, newTestEvent(prefix+"wait"))
+		}()
+		<-registrar.started
+
+		// Shutdown cancels the running command's context and waits for it to return.
+		a.NoError(router.Shutdown(context.Background()))
+		a.IsType(&ErrCommandExecution{}, <-done)
+		a.Equal(ErrRouterClosed, handle(router, newTestEvent(prefix+"add 1 2")))
+	})
+}
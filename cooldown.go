@@ -0,0 +1,184 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"sync"
+	"time"
+)
+
+// BucketScope represents what a cooldown bucket is keyed by.
+type BucketScope int
+
+const (
+	// BucketUser gives every user their own bucket.
+	BucketUser BucketScope = iota
+	// BucketChannel gives every channel it's own bucket.
+	BucketChannel
+	// BucketGuild gives every guild it's own bucket, direct messages are keyed by
+	// their channel so every DM has it's own bucket.
+	BucketGuild
+	// BucketGlobal uses a single bucket for everyone.
+	BucketGlobal
+)
+
+// String returns the name of the bucket scope.
+func (s BucketScope) String() string {
+	switch s {
+	case BucketUser:
+		return "user"
+	case BucketChannel:
+		return "channel"
+	case BucketGuild:
+		return "guild"
+	case BucketGlobal:
+		return "global"
+	default:
+		return "unknown"
+	}
+}
+
+// Cooldown represents a command cooldown, allowing a number of uses per window.
+type Cooldown struct {
+	// Uses is the amount of times the command can be used per window.
+	Uses int
+	// Window is how long it takes for the uses to reset.
+	Window time.Duration
+	// Scope is what the cooldown bucket is keyed by.
+	Scope BucketScope
+}
+
+// BucketStore represents a store for cooldown buckets.
+//
+// A BucketStore must be safe for concurrent use.
+type BucketStore interface {
+	// Take attempts to take a use from the bucket matching the key, if the bucket
+	// has no uses remaining the time until it resets is returned with false.
+	Take(key string, uses int, window time.Duration) (time.Duration, bool)
+}
+
+// WithBucketStore sets the store used for command cooldowns, by default an
+// in-memory store is used.
+func WithBucketStore(store BucketStore) Option {
+	return func(r *Router) {
+		r.buckets = store
+	}
+}
+
+// checkCooldown takes a use from the command's cooldown bucket.
+func (r *Router) checkCooldown(m Message, command *Command) error {
+	if command.cooldown == nil {
+		return nil
+	}
+
+	key := getBucketKey(m, command.name, command.cooldown.Scope)
+
+	remaining, ok := r.buckets.Take(key, command.cooldown.Uses, command.cooldown.Window)
+	if !ok {
+		return &ErrOnCooldown{
+			Command:   command.name,
+			Remaining: remaining,
+		}
+	}
+
+	return nil
+}
+
+// getBucketKey returns the key of the bucket for the message and scope.
+func getBucketKey(m Message, name string, scope BucketScope) string {
+	switch scope {
+	case BucketUser:
+		return name + ":user:" + m.AuthorID()
+	case BucketChannel:
+		return name + ":channel:" + m.ChannelID()
+	case BucketGuild:
+		// Direct messages do not have a guild, fall back to the channel so DMs
+		// do not share a single bucket.
+		if m.GuildID() == "" {
+			return name + ":channel:" + m.ChannelID()
+		}
+
+		return name + ":guild:" + m.GuildID()
+	default:
+		return name
+	}
+}
+
+// memoryBucketSweepInterval is how often expired buckets are removed from a MemoryBucketStore.
+const memoryBucketSweepInterval = time.Minute
+
+// MemoryBucketStore represents an in-memory BucketStore, buckets are removed once they expire.
+type MemoryBucketStore struct {
+	mx        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+
+	now func() time.Time
+}
+
+var _ BucketStore = (*MemoryBucketStore)(nil)
+
+// memoryBucket represents a single bucket in a MemoryBucketStore.
+type memoryBucket struct {
+	uses  int
+	reset time.Time
+}
+
+// NewMemoryBucketStore returns a new *MemoryBucketStore.
+func NewMemoryBucketStore() *MemoryBucketStore {
+	return &MemoryBucketStore{
+		buckets: make(map[string]*memoryBucket),
+
+		now: time.Now,
+	}
+}
+
+// Take attempts to take a use from the bucket matching the key.
+func (s *MemoryBucketStore) Take(key string, uses int, window time.Duration) (time.Duration, bool) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= memoryBucketSweepInterval {
+		s.sweep(now)
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok || !now.Before(bucket.reset) {
+		bucket = &memoryBucket{
+			reset: now.Add(window),
+		}
+		s.buckets[key] = bucket
+	}
+
+	if bucket.uses >= uses {
+		return bucket.reset.Sub(now), false
+	}
+
+	bucket.uses++
+	return 0, true
+}
+
+// Len returns the amount of buckets in the store.
+func (s *MemoryBucketStore) Len() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return len(s.buckets)
+}
+
+// sweep removes every expired bucket, the lock must be held by the caller.
+func (s *MemoryBucketStore) sweep(now time.Time) {
+	for key, bucket := range s.buckets {
+		if !now.Before(bucket.reset) {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.lastSweep = now
+}
@@ -0,0 +1,147 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type cooldownRegistrar struct{}
+
+func (c *cooldownRegistrar) Draw(_ *testEvent) error {
+	return nil
+}
+
+func (c *cooldownRegistrar) Roll(_ *testEvent, _ int) error {
+	return nil
+}
+
+func (c *cooldownRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *cooldownRegistrar) Arguments() map[string][]string {
+	return map[string][]string{
+		"roll": {"sides"},
+	}
+}
+
+func (c *cooldownRegistrar) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"draw": {
+			Cooldown: &Cooldown{
+				Uses:   2,
+				Window: time.Minute,
+				Scope:  BucketUser,
+			},
+		},
+		"roll": {
+			Cooldown: &Cooldown{
+				Uses:   1,
+				Window: time.Minute,
+				Scope:  BucketUser,
+			},
+		},
+	}
+}
+
+func TestMemoryBucketStore_Take(t *testing.T) {
+	a := assert.New(t)
+
+	now := time.Now()
+	store := NewMemoryBucketStore()
+	store.now = func() time.Time {
+		return now
+	}
+
+	_, ok := store.Take("key", 2, time.Minute)
+	a.True(ok)
+	_, ok = store.Take("key", 2, time.Minute)
+	a.True(ok)
+
+	remaining, ok := store.Take("key", 2, time.Minute)
+	a.False(ok)
+	a.Equal(time.Minute, remaining)
+
+	// A different key has it's own bucket.
+	_, ok = store.Take("other", 2, time.Minute)
+	a.True(ok)
+
+	a.Equal(2, store.Len())
+
+	// The bucket resets once the window has passed and expired buckets are removed.
+	now = now.Add(memoryBucketSweepInterval)
+	_, ok = store.Take("key", 2, time.Minute)
+	a.True(ok)
+	a.Equal(1, store.Len())
+}
+
+func TestRouter_checkCooldown(t *testing.T) {
+	a := assert.New(t)
+
+	router, err := New(prefix, &cooldownRegistrar{})
+	if !a.NoError(err) {
+		return
+	}
+
+	user1 := newRestrictedMessage(prefix+"draw", "1", "", "0")
+	user2 := newRestrictedMessage(prefix+"draw", "2", "", "0")
+
+	a.NoError(handle(router, user1))
+	a.NoError(handle(router, user1))
+
+	err = handle(router, user1)
+	if a.IsType(&ErrOnCooldown{}, err) {
+		a.Equal("draw", err.(*ErrOnCooldown).Command)
+		a.True(err.(*ErrOnCooldown).Remaining > 0)
+	}
+
+	a.NoError(handle(router, user2))
+}
+
+func TestRouter_checkCooldown_BeforeArguments(t *testing.T) {
+	a := assert.New(t)
+
+	router, err := New(prefix, &cooldownRegistrar{}, WithWorkerPool(WorkerPoolConfig{Workers: 1}))
+	if !a.NoError(err) {
+		return
+	}
+	defer router.Shutdown(context.Background())
+
+	a.NoError(handle(router, newRestrictedMessage(prefix+"roll 6", "1", "", "0")))
+
+	// Commands on cooldown are rejected by Handle before their arguments are parsed or queued.
+	a.IsType(&ErrOnCooldown{}, handle(router, newRestrictedMessage(prefix+"roll six", "1", "", "0")))
+}
+
+func TestGetBucketKey(t *testing.T) {
+	a := assert.New(t)
+
+	guild := newRestrictedMessage(prefix+"draw", "1", "2", "3")
+	dm := newRestrictedMessage(prefix+"draw", "1", "", "4")
+	other := newRestrictedMessage(prefix+"draw", "5", "", "6")
+
+	a.Equal("draw:user:1", getBucketKey(guild, "draw", BucketUser))
+	a.Equal("draw:channel:3", getBucketKey(guild, "draw", BucketChannel))
+	a.Equal("draw:guild:2", getBucketKey(guild, "draw", BucketGuild))
+	a.Equal("draw", getBucketKey(guild, "draw", BucketGlobal))
+
+	// Direct messages are keyed by their channel instead of sharing an empty guild.
+	a.Equal("draw:channel:4", getBucketKey(dm, "draw", BucketGuild))
+	a.NotEqual(getBucketKey(dm, "draw", BucketGuild), getBucketKey(other, "draw", BucketGuild))
+}
+
+func TestErrOnCooldown_Error(t *testing.T) {
+	a := assert.New(t)
+
+	err := &ErrOnCooldown{Command: "draw", Remaining: 1500 * time.Millisecond}
+	a.Equal("`draw` is on cooldown, try again in 2s", err.Error())
+}
@@ -0,0 +1,69 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"github.com/andersfylling/disgord"
+	"go.matthewp.io/router"
+)
+
+// React adds a reaction to the message that invoked the command, emoji is
+// either a unicode emoji or a *disgord.Emoji. ErrNotDiscord is returned if the
+// command was not invoked from Discord.
+func React(ctx *router.Context, emoji interface{}) error {
+	client, e, err := getContextClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return client.CreateReaction(ctx, e.Message.ChannelID, e.Message.ID, emoji)
+}
+
+// DM sends a direct message to the user that invoked the command, data is
+// handled the same way as disgord's SendMsg.
+func DM(ctx *router.Context, data ...interface{}) (*disgord.Message, error) {
+	client, e, err := getContextClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := client.CreateDM(ctx, e.Message.Author.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.SendMsg(ctx, channel.ID, data...)
+}
+
+// Typing triggers the typing indicator in the channel the command was invoked in.
+func Typing(ctx *router.Context) error {
+	client, e, err := getContextClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return client.TriggerTypingIndicator(ctx, e.Message.ChannelID)
+}
+
+// getContextClient returns the client of the transport the command was invoked
+// by and the event of it's message.
+func getContextClient(ctx *router.Context) (Session, *disgord.MessageCreate, error) {
+	e := Event(ctx.Message)
+	if e == nil {
+		return nil, nil, ErrNotDiscord
+	}
+
+	switch t := ctx.Transport.(type) {
+	case *Transport:
+		return t.Client, e, nil
+	case *interactionTransport:
+		return t.transport.Client, e, nil
+	default:
+		return nil, nil, ErrNotDiscord
+	}
+}
@@ -0,0 +1,29 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"github.com/andersfylling/disgord"
+	"strings"
+)
+
+// ErrMissingPermissions represents a Missing Permissions error.
+type ErrMissingPermissions struct {
+	// Bot is true if the bot is missing the permissions rather than the user.
+	Bot     bool
+	Missing disgord.PermissionBits
+}
+
+func (err *ErrMissingPermissions) Error() string {
+	who := "You are"
+	if err.Bot {
+		who = "I am"
+	}
+
+	return who + " missing the following permissions: `" + strings.Join(PermissionNames(err.Missing), "`, `") + "`"
+}
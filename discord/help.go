@@ -0,0 +1,82 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"github.com/andersfylling/disgord"
+	"go.matthewp.io/router"
+	"unicode/utf8"
+)
+
+// Discord's limits on the length of the parts of an embed.
+const (
+	maxEmbedLength            = 6000
+	maxEmbedTitleLength       = 256
+	maxEmbedDescriptionLength = 2048
+	maxEmbedFields            = 25
+	maxEmbedFieldNameLength   = 256
+	maxEmbedFieldValueLength  = 1024
+	maxEmbedFooterLength      = 2048
+)
+
+// getHelpEmbed renders the help message of the built-in help command as an embed,
+// every part of the embed is truncated to Discord's limits and fields past the
+// limit are dropped.
+func getHelpEmbed(m *router.HelpMessage) *disgord.Embed {
+	// remaining is what's left of the limit on the total length of the embed.
+	remaining := maxEmbedLength
+	take := func(s string, limit int) string {
+		if limit > remaining {
+			limit = remaining
+		}
+
+		s = truncate(s, limit)
+		remaining -= utf8.RuneCountInString(s)
+		return s
+	}
+
+	embed := &disgord.Embed{
+		Title: take(m.Title, maxEmbedTitleLength),
+		Color: m.Color,
+	}
+
+	if m.Footer != "" {
+		embed.Footer = &disgord.EmbedFooter{
+			Text: take(m.Footer, maxEmbedFooterLength),
+		}
+	}
+
+	embed.Description = take(m.Description, maxEmbedDescriptionLength)
+
+	for _, field := range m.Fields {
+		if len(embed.Fields) == maxEmbedFields || remaining < 2 {
+			break
+		}
+
+		embed.Fields = append(embed.Fields, &disgord.EmbedField{
+			Name:  take(field.Name, maxEmbedFieldNameLength),
+			Value: take(field.Value, maxEmbedFieldValueLength),
+		})
+	}
+
+	return embed
+}
+
+// truncate shortens s to at most n characters, ending it with an ellipsis if it was shortened.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	if n <= 3 {
+		return string(runes[:n])
+	}
+
+	return string(runes[:n-3]) + "..."
+}
@@ -0,0 +1,93 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"context"
+	"github.com/andersfylling/disgord"
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router"
+	"strings"
+	"testing"
+)
+
+func TestGetHelpEmbed(t *testing.T) {
+	a := assert.New(t)
+
+	message := &router.HelpMessage{
+		Title:       strings.Repeat("t", 300),
+		Description: strings.Repeat("d", 3000),
+		Footer:      "footer",
+		Color:       0x5865f2,
+	}
+	for i := 0; i < 30; i++ {
+		message.Fields = append(message.Fields, &router.HelpField{Name: "Field", Value: strings.Repeat("v", 2000)})
+	}
+
+	embed := getHelpEmbed(message)
+	a.Len(embed.Title, maxEmbedTitleLength)
+	a.Len(embed.Description, maxEmbedDescriptionLength)
+	a.Equal("footer", embed.Footer.Text)
+	a.Equal(0x5865f2, embed.Color)
+
+	length := len(embed.Title) + len(embed.Description) + len(embed.Footer.Text)
+	for _, field := range embed.Fields {
+		a.True(len(field.Value) <= maxEmbedFieldValueLength)
+		length += len(field.Name) + len(field.Value)
+	}
+	a.True(len(embed.Fields) <= maxEmbedFields)
+	a.True(length <= maxEmbedLength, "embed is %d characters", length)
+}
+
+type helpCommands struct{}
+
+func (c *helpCommands) Ping(_ router.Message) error {
+	return nil
+}
+
+func (c *helpCommands) Descriptions() map[string]string {
+	return map[string]string{
+		"ping": "Replies with Pong! if the bot is online",
+	}
+}
+
+func (c *helpCommands) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+func TestRouter_Handle_Help(t *testing.T) {
+	for _, embed := range []bool{false, true} {
+		a := assert.New(t)
+
+		r, err := NewRouter(&disgord.Client{}, prefix, &helpCommands{}, router.WithHelp(router.HelpConfig{Embed: embed}))
+		if !a.NoError(err) {
+			return
+		}
+
+		// Help is sent using the transport's ReplySender instead of the client.
+		var replies []*disgord.CreateMessageParams
+		r.Transport.Replies = ReplySenderFunc(func(_ context.Context, _ *disgord.MessageCreate, params *disgord.CreateMessageParams) error {
+			replies = append(replies, params)
+			return nil
+		})
+
+		a.NoError(r.Handle(newMessageCreate(prefix + "help")))
+		if !a.Len(replies, 1) {
+			continue
+		}
+
+		if embed {
+			if a.NotNil(replies[0].Embed) && a.Len(replies[0].Embed.Fields, 1) {
+				a.Contains(replies[0].Embed.Fields[0].Value, "`"+prefix+"ping`")
+			}
+		} else {
+			a.Nil(replies[0].Embed)
+			a.Contains(replies[0].Content, "**Commands**")
+		}
+	}
+}
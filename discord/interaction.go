@@ -0,0 +1,388 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/andersfylling/disgord"
+	"go.matthewp.io/router"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// maxApplicationCommandDescription is the maximum length of an application command's description.
+const maxApplicationCommandDescription = 100
+
+// ApplicationCommandOptionType represents the type of an application command option.
+type ApplicationCommandOptionType int
+
+const (
+	OptionString  ApplicationCommandOptionType = 3
+	OptionInteger ApplicationCommandOptionType = 4
+	OptionBoolean ApplicationCommandOptionType = 5
+	OptionUser    ApplicationCommandOptionType = 6
+	OptionChannel ApplicationCommandOptionType = 7
+	OptionRole    ApplicationCommandOptionType = 8
+	OptionNumber  ApplicationCommandOptionType = 10
+)
+
+// ApplicationCommand represents the schema of a Discord application (slash) command.
+type ApplicationCommand struct {
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Options     []*ApplicationCommandOption `json:"options,omitempty"`
+}
+
+// ApplicationCommandOption represents an option of an application command.
+type ApplicationCommandOption struct {
+	Type        ApplicationCommandOptionType      `json:"type"`
+	Name        string                            `json:"name"`
+	Description string                            `json:"description"`
+	Required    bool                              `json:"required,omitempty"`
+	Choices     []*ApplicationCommandOptionChoice `json:"choices,omitempty"`
+}
+
+// ApplicationCommandOptionChoice represents a choice of an application command option,
+// Value is either a string or a number depending on the option's type.
+type ApplicationCommandOptionChoice = router.Choice
+
+// InteractionType represents the type of an interaction.
+type InteractionType int
+
+const (
+	InteractionPing               InteractionType = 1
+	InteractionApplicationCommand InteractionType = 2
+)
+
+// Interaction represents the payload of an INTERACTION_CREATE event or an
+// interaction received by an outgoing webhook.
+type Interaction struct {
+	ID            disgord.Snowflake `json:"id"`
+	ApplicationID disgord.Snowflake `json:"application_id"`
+	Type          InteractionType   `json:"type"`
+	Data          *InteractionData  `json:"data,omitempty"`
+	GuildID       disgord.Snowflake `json:"guild_id,omitempty"`
+	ChannelID     disgord.Snowflake `json:"channel_id,omitempty"`
+	// Member is the member that invoked the interaction in a guild.
+	Member *disgord.Member `json:"member,omitempty"`
+	// User is the user that invoked the interaction in a direct message.
+	User  *disgord.User `json:"user,omitempty"`
+	Token string        `json:"token"`
+}
+
+// author returns the user that invoked the interaction, nil if the interaction
+// has neither a user nor a member.
+func (i *Interaction) author() *disgord.User {
+	if i.User != nil {
+		return i.User
+	}
+
+	if i.Member != nil {
+		return i.Member.User
+	}
+
+	return nil
+}
+
+// InteractionData represents the data of an application command interaction.
+type InteractionData struct {
+	ID      disgord.Snowflake        `json:"id"`
+	Name    string                   `json:"name"`
+	Options []*InteractionDataOption `json:"options,omitempty"`
+}
+
+// InteractionDataOption represents an option passed to an application command.
+type InteractionDataOption struct {
+	Name  string                       `json:"name"`
+	Type  ApplicationCommandOptionType `json:"type"`
+	Value json.RawMessage              `json:"value,omitempty"`
+}
+
+// InteractionResponseType represents the type of an interaction response.
+type InteractionResponseType int
+
+const (
+	InteractionResponsePong                             InteractionResponseType = 1
+	InteractionResponseChannelMessageWithSource         InteractionResponseType = 4
+	InteractionResponseDeferredChannelMessageWithSource InteractionResponseType = 5
+)
+
+// InteractionResponse represents a response to an interaction.
+type InteractionResponse struct {
+	Type InteractionResponseType  `json:"type"`
+	Data *InteractionResponseData `json:"data,omitempty"`
+}
+
+// InteractionResponseData represents the message sent in response to an interaction.
+type InteractionResponseData struct {
+	Content string           `json:"content,omitempty"`
+	Embeds  []*disgord.Embed `json:"embeds,omitempty"`
+}
+
+// InteractionResponder represents something that responds to interactions,
+// either by calling Discord's interaction callback endpoint or by writing the
+// response of an outgoing webhook.
+type InteractionResponder interface {
+	Respond(ctx context.Context, i *Interaction, response *InteractionResponse) error
+}
+
+// InteractionResponderFunc is an adapter to allow the use of an ordinary function as an InteractionResponder.
+type InteractionResponderFunc func(ctx context.Context, i *Interaction, response *InteractionResponse) error
+
+// Respond calls f(ctx, i, response).
+func (f InteractionResponderFunc) Respond(ctx context.Context, i *Interaction, response *InteractionResponse) error {
+	return f(ctx, i, response)
+}
+
+// NewApplicationCommand returns the application command schema of the command,
+// every argument is an option that is required unless the argument is optional.
+// The command's name is used as it's description if it does not have one.
+func NewApplicationCommand(c *router.Command) *ApplicationCommand {
+	command := &ApplicationCommand{
+		Name:        c.Name(),
+		Description: getApplicationCommandDescription(c.Description, c.Name()),
+	}
+
+	choices := c.Choices()
+	for _, argument := range c.Arguments() {
+		command.Options = append(command.Options, &ApplicationCommandOption{
+			Type:        getOptionType(argument),
+			Name:        strings.ToLower(argument.Name()),
+			Description: getApplicationCommandDescription(argument.Description, argument.Name()),
+			Required:    !argument.Optional(),
+			Choices:     choices[argument.Name()],
+		})
+	}
+
+	return command
+}
+
+// ApplicationCommands returns the application command schema of every command
+// that is not hidden, used to register the router's commands with Discord.
+func (r *Router) ApplicationCommands() []*ApplicationCommand {
+	var commands []*ApplicationCommand
+	for _, command := range r.GetCommands() {
+		if command.Hidden() {
+			continue
+		}
+
+		commands = append(commands, NewApplicationCommand(command))
+	}
+
+	return commands
+}
+
+// HandleInteraction handles an interaction, application commands are ran by
+// the command with the same name and their options are bound to the command's
+// arguments by name, see router.Router#HandleCommand. Pings are responded to
+// with a pong.
+//
+// The first reply of the command, either returned by it or sent using Context's
+// Reply method, is sent as the interaction's response and any further replies
+// are sent as messages using the transport's ReplySender. If the command returns
+// without replying, fails or panics, the interaction is acknowledged with a
+// deferred response and the error is returned, or passed to the worker pool's
+// OnError callback, so it can be reported by editing the response using the
+// interaction's token. Errors that occur before the command is ran, such as an
+// unknown command or invalid options, are returned without responding and the
+// caller should respond with them like it would for Handle.
+func (r *Router) HandleInteraction(ctx context.Context, responder InteractionResponder, i *Interaction) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	switch {
+	case i.Type == InteractionPing:
+		return responder.Respond(ctx, i, &InteractionResponse{Type: InteractionResponsePong})
+	case i.Type != InteractionApplicationCommand || i.Data == nil:
+		return ErrUnsupportedInteraction
+	case i.author() == nil:
+		return ErrMissingInteractionUser
+	}
+
+	arguments := make(map[string]string, len(i.Data.Options))
+	for _, option := range i.Data.Options {
+		arguments[option.Name] = option.input()
+	}
+
+	t := &interactionTransport{
+		transport:   r.Transport,
+		responder:   responder,
+		interaction: i,
+	}
+
+	return r.HandleCommand(ctx, t, NewMessage(getInteractionEvent(ctx, r.Prefix, i)), i.Data.Name, arguments)
+}
+
+// getInteractionEvent returns the event passed to the command for an
+// interaction, the message's content is the command's name and options.
+func getInteractionEvent(ctx context.Context, prefix string, i *Interaction) *disgord.MessageCreate {
+	message := &disgord.Message{
+		ID:        i.ID,
+		ChannelID: i.ChannelID,
+		GuildID:   i.GuildID,
+		Author:    i.author(),
+	}
+
+	if i.Member != nil {
+		message.Member = &disgord.Member{
+			GuildID: i.GuildID,
+			User:    i.Member.User,
+			Nick:    i.Member.Nick,
+			Roles:   i.Member.Roles,
+		}
+	}
+
+	content := prefix + strings.ToLower(i.Data.Name)
+	for _, option := range i.Data.Options {
+		content += " " + option.input()
+	}
+	message.Content = content
+
+	return &disgord.MessageCreate{
+		Message: message,
+		Ctx:     ctx,
+	}
+}
+
+// input returns the option's value as the input of an argument, users, channels
+// and roles are formatted as mentions.
+func (o *InteractionDataOption) input() string {
+	var value string
+	if err := json.Unmarshal(o.Value, &value); err != nil {
+		// Numbers and booleans are passed as their JSON representation.
+		value = string(o.Value)
+	}
+
+	switch o.Type {
+	case OptionUser:
+		return "<@" + value + ">"
+	case OptionChannel:
+		return "<#" + value + ">"
+	case OptionRole:
+		return "<@&" + value + ">"
+	default:
+		return value
+	}
+}
+
+// interactionTransport is the transport of commands ran by an interaction, it
+// sends the first reply as the interaction's response.
+type interactionTransport struct {
+	// transport checks the command's restrictions and sends the replies after
+	// the first, as an interaction can only be responded to once.
+	transport   *Transport
+	responder   InteractionResponder
+	interaction *Interaction
+
+	mx        sync.Mutex
+	responded bool
+}
+
+var (
+	_ router.ReplyTransport  = (*interactionTransport)(nil)
+	_ router.CheckTransport  = (*interactionTransport)(nil)
+	_ router.FinishTransport = (*interactionTransport)(nil)
+)
+
+// Reply responds to the interaction with the content.
+func (t *interactionTransport) Reply(ctx context.Context, m router.Message, content string) error {
+	return t.SendReply(ctx, m, content)
+}
+
+// SendReply responds to the interaction with the reply, or sends the reply
+// using the transport if the interaction has already been responded to.
+func (t *interactionTransport) SendReply(ctx context.Context, m router.Message, data ...interface{}) error {
+	if !t.respond() {
+		return t.transport.SendReply(ctx, m, data...)
+	}
+
+	params, err := getMessageParams(data...)
+	if err != nil {
+		return err
+	}
+
+	if len(params.Files) > 0 {
+		return router.ErrUnsupportedReply
+	}
+
+	response := &InteractionResponseData{
+		Content: params.Content,
+	}
+	if params.Embed != nil {
+		response.Embeds = []*disgord.Embed{params.Embed}
+	}
+
+	return t.responder.Respond(ctx, t.interaction, &InteractionResponse{
+		Type: InteractionResponseChannelMessageWithSource,
+		Data: response,
+	})
+}
+
+// Check checks the command's restrictions using the transport.
+func (t *interactionTransport) Check(ctx context.Context, m router.Message, command *router.Command) error {
+	return t.transport.Check(ctx, m, command)
+}
+
+// Finish responds to the interaction with a deferred response if the command
+// did not reply, Discord reports the interaction as failed otherwise. Failures
+// can be reported by editing the deferred response.
+func (t *interactionTransport) Finish(ctx context.Context, _ router.Message, _ error) error {
+	if !t.respond() {
+		return nil
+	}
+
+	return t.responder.Respond(ctx, t.interaction, &InteractionResponse{
+		Type: InteractionResponseDeferredChannelMessageWithSource,
+	})
+}
+
+// respond marks the interaction as responded to, false is returned if it already was.
+func (t *interactionTransport) respond() bool {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.responded {
+		return false
+	}
+
+	t.responded = true
+	return true
+}
+
+// getOptionType returns the application command option type of an argument,
+// arguments that do not set their option type use the type matching their kind.
+func getOptionType(argument *router.Argument) ApplicationCommandOptionType {
+	if option := argument.OptionType(); option != 0 {
+		return ApplicationCommandOptionType(option)
+	}
+
+	switch argument.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return OptionInteger
+	case reflect.Float32, reflect.Float64:
+		return OptionNumber
+	case reflect.Bool:
+		return OptionBoolean
+	default:
+		return OptionString
+	}
+}
+
+// getApplicationCommandDescription returns a description that fits the limits
+// of an application command, fallback is used if the description is empty.
+func getApplicationCommandDescription(description, fallback string) string {
+	if description == "" {
+		description = fallback
+	}
+
+	return truncate(description, maxApplicationCommandDescription)
+}
@@ -0,0 +1,298 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/andersfylling/disgord"
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router"
+	"go.matthewp.io/router/args"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+type interactionCommands struct{}
+
+func (c *interactionCommands) Poke(e *disgord.MessageCreate, user *args.UserMention, times int) (string, error) {
+	return fmt.Sprintf("%s poked %s %d times", e.Message.Author.Username, user.Snowflake(), times), nil
+}
+
+func (c *interactionCommands) Echo(_ router.Message, text string) (string, error) {
+	return text, nil
+}
+
+func (c *interactionCommands) Secret(_ *disgord.MessageCreate) error {
+	return nil
+}
+
+func (c *interactionCommands) Descriptions() map[string]string {
+	return map[string]string{
+		"poke": "Pokes a user",
+	}
+}
+
+func (c *interactionCommands) Arguments() map[string][]string {
+	return map[string][]string{
+		"poke": {"user", "times"},
+		"echo": {"text"},
+	}
+}
+
+func (c *interactionCommands) Metadata() map[string]router.Metadata {
+	return map[string]router.Metadata{
+		"poke": {
+			ArgumentDescriptions: map[string]string{
+				"user": "The user to poke",
+			},
+			Optional: []string{"times"},
+			Choices: map[string][]*ApplicationCommandOptionChoice{
+				"times": {
+					{Name: "Once", Value: 1},
+					{Name: "Thrice", Value: 3},
+				},
+			},
+		},
+		"secret": {
+			Hidden: true,
+		},
+	}
+}
+
+// readInteraction reads an interaction from the testdata/interactions directory.
+func readInteraction(t *testing.T, name string) *Interaction {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "interactions", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := &Interaction{}
+	if err := json.Unmarshal(data, i); err != nil {
+		t.Fatal(err)
+	}
+
+	return i
+}
+
+func TestRouter_ApplicationCommands(t *testing.T) {
+	a := assert.New(t)
+
+	r, err := NewRouter(&disgord.Client{}, prefix, &interactionCommands{})
+	if !a.NoError(err) {
+		return
+	}
+
+	commands := r.ApplicationCommands()
+	if !a.Len(commands, 2) {
+		return
+	}
+
+	byName := map[string]*ApplicationCommand{}
+	for _, command := range commands {
+		byName[command.Name] = command
+	}
+
+	a.Equal(&ApplicationCommand{
+		Name:        "poke",
+		Description: "Pokes a user",
+		Options: []*ApplicationCommandOption{
+			{
+				Type:        OptionUser,
+				Name:        "user",
+				Description: "The user to poke",
+				Required:    true,
+			},
+			{
+				Type:        OptionInteger,
+				Name:        "times",
+				Description: "times",
+				Choices: []*ApplicationCommandOptionChoice{
+					{Name: "Once", Value: 1},
+					{Name: "Thrice", Value: 3},
+				},
+			},
+		},
+	}, byName["poke"])
+
+	if a.Contains(byName, "echo") {
+		a.Equal("echo", byName["echo"].Description)
+		a.Equal(OptionString, byName["echo"].Options[0].Type)
+	}
+
+	data, err := json.Marshal(byName["echo"])
+	a.NoError(err)
+	a.JSONEq(`{"name":"echo","description":"echo","options":[{"type":3,"name":"text","description":"text","required":true}]}`, string(data))
+}
+
+func TestRouter_HandleInteraction(t *testing.T) {
+	var responses []*InteractionResponse
+	responder := InteractionResponderFunc(func(_ context.Context, _ *Interaction, response *InteractionResponse) error {
+		responses = append(responses, response)
+		return nil
+	})
+
+	r, err := NewRouter(&disgord.Client{}, prefix, &interactionCommands{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Ping", func(t *testing.T) {
+		a := assert.New(t)
+
+		responses = nil
+		a.NoError(r.HandleInteraction(context.Background(), responder, readInteraction(t, "ping")))
+		a.Equal([]*InteractionResponse{{Type: InteractionResponsePong}}, responses)
+	})
+
+	t.Run("Guild", func(t *testing.T) {
+		a := assert.New(t)
+
+		responses = nil
+		a.NoError(r.HandleInteraction(context.Background(), responder, readInteraction(t, "poke")))
+		if a.Len(responses, 1) {
+			a.Equal(InteractionResponseChannelMessageWithSource, responses[0].Type)
+			a.Equal("Mason poked 53908232506183680 3 times", responses[0].Data.Content)
+		}
+	})
+
+	t.Run("Optional", func(t *testing.T) {
+		a := assert.New(t)
+
+		i := readInteraction(t, "poke")
+		i.Data.Options = i.Data.Options[:1]
+
+		responses = nil
+		a.NoError(r.HandleInteraction(context.Background(), responder, i))
+		if a.Len(responses, 1) {
+			a.Equal("Mason poked 53908232506183680 0 times", responses[0].Data.Content)
+		}
+	})
+
+	t.Run("DM", func(t *testing.T) {
+		a := assert.New(t)
+
+		responses = nil
+		a.NoError(r.HandleInteraction(context.Background(), responder, readInteraction(t, "echo")))
+		if a.Len(responses, 1) {
+			a.Equal("hello world", responses[0].Data.Content)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		a := assert.New(t)
+
+		responses = nil
+		a.IsType(&router.ErrMissingArguments{}, r.HandleInteraction(context.Background(), responder, readInteraction(t, "missing")))
+		a.Equal(ErrUnsupportedInteraction, r.HandleInteraction(context.Background(), responder, readInteraction(t, "component")))
+
+		i := readInteraction(t, "echo")
+		i.Data.Name = "unknown"
+		a.IsType(&router.ErrUnknownCommand{}, r.HandleInteraction(context.Background(), responder, i))
+
+		i = readInteraction(t, "echo")
+		i.User = nil
+		a.Equal(ErrMissingInteractionUser, r.HandleInteraction(context.Background(), responder, i))
+
+		a.Empty(responses)
+	})
+}
+
+type interactionReplyCommands struct{}
+
+func (c *interactionReplyCommands) Echo(ctx *router.Context, text string) error {
+	if err := ctx.Reply(text); err != nil {
+		return err
+	}
+
+	return ctx.Reply(&disgord.Embed{Description: text})
+}
+
+func (c *interactionReplyCommands) Quiet(_ *disgord.MessageCreate) error {
+	return nil
+}
+
+func (c *interactionReplyCommands) Fail(_ *disgord.MessageCreate) error {
+	return errors.New("failed")
+}
+
+func (c *interactionReplyCommands) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *interactionReplyCommands) Arguments() map[string][]string {
+	return map[string][]string{
+		"echo": {"text"},
+	}
+}
+
+func TestRouter_HandleInteraction_Replies(t *testing.T) {
+	var responses []*InteractionResponse
+	responder := InteractionResponderFunc(func(_ context.Context, _ *Interaction, response *InteractionResponse) error {
+		responses = append(responses, response)
+		return nil
+	})
+
+	r, err := NewRouter(&disgord.Client{}, prefix, &interactionReplyCommands{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var replies []*disgord.CreateMessageParams
+	r.Transport.Replies = ReplySenderFunc(func(_ context.Context, e *disgord.MessageCreate, params *disgord.CreateMessageParams) error {
+		if e == nil || e.Message.ChannelID != 645027906669510668 {
+			return fmt.Errorf("unexpected event %v", e)
+		}
+
+		replies = append(replies, params)
+		return nil
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		a := assert.New(t)
+
+		responses, replies = nil, nil
+		a.NoError(r.HandleInteraction(context.Background(), responder, readInteraction(t, "echo")))
+		if a.Len(responses, 1) {
+			a.Equal(InteractionResponseChannelMessageWithSource, responses[0].Type)
+			a.Equal("hello world", responses[0].Data.Content)
+		}
+		if a.Len(replies, 1) && a.NotNil(replies[0].Embed) {
+			a.Equal("hello world", replies[0].Embed.Description)
+		}
+	})
+
+	t.Run("Deferred", func(t *testing.T) {
+		a := assert.New(t)
+
+		i := readInteraction(t, "echo")
+		i.Data.Name = "quiet"
+		i.Data.Options = nil
+
+		responses, replies = nil, nil
+		a.NoError(r.HandleInteraction(context.Background(), responder, i))
+		a.Equal([]*InteractionResponse{{Type: InteractionResponseDeferredChannelMessageWithSource}}, responses)
+		a.Empty(replies)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		a := assert.New(t)
+
+		i := readInteraction(t, "echo")
+		i.Data.Name = "fail"
+		i.Data.Options = nil
+
+		// Failed commands are acknowledged so the failure can be reported by editing the response.
+		responses, replies = nil, nil
+		a.IsType(&router.ErrCommandExecution{}, r.HandleInteraction(context.Background(), responder, i))
+		a.Equal([]*InteractionResponse{{Type: InteractionResponseDeferredChannelMessageWithSource}}, responses)
+	})
+}
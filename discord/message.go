@@ -0,0 +1,47 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"github.com/andersfylling/disgord"
+)
+
+// Message represents a Discord message as a router.Message.
+type Message struct {
+	Event *disgord.MessageCreate
+}
+
+// NewMessage returns the message of a MessageCreate event.
+func NewMessage(e *disgord.MessageCreate) *Message {
+	return &Message{Event: e}
+}
+
+// Content returns the text of the message.
+func (m *Message) Content() string {
+	return m.Event.Message.Content
+}
+
+// AuthorID returns the ID of the user that sent the message.
+func (m *Message) AuthorID() string {
+	return m.Event.Message.Author.ID.String()
+}
+
+// ChannelID returns the ID of the channel the message was sent in.
+func (m *Message) ChannelID() string {
+	return m.Event.Message.ChannelID.String()
+}
+
+// GuildID returns the ID of the guild the message was sent in, the ID is empty
+// if the message is a direct message.
+func (m *Message) GuildID() string {
+	if m.Event.Message.GuildID.IsZero() {
+		return ""
+	}
+
+	return m.Event.Message.GuildID.String()
+}
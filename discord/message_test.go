@@ -0,0 +1,41 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"github.com/andersfylling/disgord"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMessage(t *testing.T) {
+	newMessage := func(guildID disgord.Snowflake) *Message {
+		return NewMessage(&disgord.MessageCreate{
+			Message: &disgord.Message{
+				Content:   "hello",
+				Author:    &disgord.User{ID: 1},
+				ChannelID: 2,
+				GuildID:   guildID,
+			},
+		})
+	}
+
+	t.Run("Guild", func(t *testing.T) {
+		a := assert.New(t)
+
+		m := newMessage(3)
+		a.Equal("hello", m.Content())
+		a.Equal("1", m.AuthorID())
+		a.Equal("2", m.ChannelID())
+		a.Equal("3", m.GuildID())
+	})
+
+	t.Run("DM", func(t *testing.T) {
+		assert.Empty(t, newMessage(0).GuildID())
+	})
+}
@@ -0,0 +1,179 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"context"
+	"fmt"
+	"github.com/andersfylling/disgord"
+	"go.matthewp.io/router"
+)
+
+// permissionNames is used to get a human readable name for a permission.
+var permissionNames = []struct {
+	bit  disgord.PermissionBit
+	name string
+}{
+	{disgord.PermissionCreateInstantInvite, "Create Instant Invite"},
+	{disgord.PermissionKickMembers, "Kick Members"},
+	{disgord.PermissionBanMembers, "Ban Members"},
+	{disgord.PermissionAdministrator, "Administrator"},
+	{disgord.PermissionManageChannels, "Manage Channels"},
+	{disgord.PermissionManageServer, "Manage Server"},
+	{disgord.PermissionAddReactions, "Add Reactions"},
+	{disgord.PermissionViewAuditLogs, "View Audit Log"},
+	{disgord.PermissionVoicePrioritySpeaker, "Priority Speaker"},
+	{disgord.PermissionReadMessages, "Read Messages"},
+	{disgord.PermissionSendMessages, "Send Messages"},
+	{disgord.PermissionSendTTSMessages, "Send TTS Messages"},
+	{disgord.PermissionManageMessages, "Manage Messages"},
+	{disgord.PermissionEmbedLinks, "Embed Links"},
+	{disgord.PermissionAttachFiles, "Attach Files"},
+	{disgord.PermissionReadMessageHistory, "Read Message History"},
+	{disgord.PermissionMentionEveryone, "Mention Everyone"},
+	{disgord.PermissionUseExternalEmojis, "Use External Emojis"},
+	{disgord.PermissionVoiceConnect, "Connect"},
+	{disgord.PermissionVoiceSpeak, "Speak"},
+	{disgord.PermissionVoiceMuteMembers, "Mute Members"},
+	{disgord.PermissionVoiceDeafenMembers, "Deafen Members"},
+	{disgord.PermissionVoiceMoveMembers, "Move Members"},
+	{disgord.PermissionVoiceUseVAD, "Use Voice Activity"},
+	{disgord.PermissionChangeNickname, "Change Nickname"},
+	{disgord.PermissionManageNicknames, "Manage Nicknames"},
+	{disgord.PermissionManageRoles, "Manage Roles"},
+	{disgord.PermissionManageWebhooks, "Manage Webhooks"},
+	{disgord.PermissionManageEmojis, "Manage Emojis"},
+}
+
+// PermissionNames returns the human readable names of every permission in the permission bits.
+func PermissionNames(permissions disgord.PermissionBits) []string {
+	var names []string
+	for _, p := range permissionNames {
+		if permissions&p.bit == p.bit {
+			names = append(names, p.name)
+		}
+	}
+
+	return names
+}
+
+// checkPermissions checks if the author of the message and the bot have the
+// permissions required by the command in the message's channel.
+func (t *Transport) checkPermissions(ctx context.Context, e *disgord.MessageCreate, command *router.Command) error {
+	required, botRequired := command.Permissions(), command.BotPermissions()
+	if required == 0 && botRequired == 0 {
+		return nil
+	}
+
+	// Permissions can only be satisfied inside of a guild.
+	if e.Message.GuildID.IsZero() {
+		if required != 0 {
+			return &ErrMissingPermissions{Missing: required}
+		}
+
+		return &ErrMissingPermissions{Bot: true, Missing: botRequired}
+	}
+
+	guild, err := t.Client.GetGuild(ctx, e.Message.GuildID)
+	if err != nil {
+		return fmt.Errorf("discord: failed to get guild: %v", err)
+	}
+
+	roles, err := t.Client.GetGuildRoles(ctx, e.Message.GuildID)
+	if err != nil {
+		return fmt.Errorf("discord: failed to get guild roles: %v", err)
+	}
+
+	channel, err := t.Client.GetChannel(ctx, e.Message.ChannelID)
+	if err != nil {
+		return fmt.Errorf("discord: failed to get channel: %v", err)
+	}
+
+	if required != 0 {
+		member, err := t.Client.GetMember(ctx, e.Message.GuildID, e.Message.Author.ID)
+		if err != nil {
+			return fmt.Errorf("discord: failed to get member: %v", err)
+		}
+
+		permissions := computePermissions(guild, roles, channel, e.Message.Author.ID, member.Roles)
+		if missing := required &^ permissions; missing != 0 {
+			return &ErrMissingPermissions{Missing: missing}
+		}
+	}
+
+	if botRequired != 0 {
+		bot, err := t.Client.GetCurrentUser(ctx)
+		if err != nil {
+			return fmt.Errorf("discord: failed to get current user: %v", err)
+		}
+
+		member, err := t.Client.GetMember(ctx, e.Message.GuildID, bot.ID)
+		if err != nil {
+			return fmt.Errorf("discord: failed to get member: %v", err)
+		}
+
+		permissions := computePermissions(guild, roles, channel, bot.ID, member.Roles)
+		if missing := botRequired &^ permissions; missing != 0 {
+			return &ErrMissingPermissions{Bot: true, Missing: missing}
+		}
+	}
+
+	return nil
+}
+
+// computePermissions computes a member's permissions in a channel, taking the
+// channel's permission overwrites into account.
+func computePermissions(guild *disgord.Guild, roles []*disgord.Role, channel *disgord.Channel, userID disgord.Snowflake, memberRoles []disgord.Snowflake) disgord.PermissionBits {
+	if guild.OwnerID == userID {
+		return disgord.PermissionAll
+	}
+
+	hasRole := make(map[disgord.Snowflake]bool, len(memberRoles))
+	for _, id := range memberRoles {
+		hasRole[id] = true
+	}
+
+	// The @everyone role has the same ID as the guild.
+	var permissions disgord.PermissionBits
+	for _, role := range roles {
+		if role.ID == guild.ID || hasRole[role.ID] {
+			permissions |= role.Permissions
+		}
+	}
+
+	if permissions&disgord.PermissionAdministrator == disgord.PermissionAdministrator {
+		return disgord.PermissionAll
+	}
+
+	if channel == nil {
+		return permissions
+	}
+
+	// Overwrites are applied in order of @everyone, roles and then the member.
+	var allow, deny disgord.PermissionBits
+	for _, overwrite := range channel.PermissionOverwrites {
+		if overwrite.ID == guild.ID {
+			permissions &^= overwrite.Deny
+			permissions |= overwrite.Allow
+		} else if overwrite.Type == "role" && hasRole[overwrite.ID] {
+			allow |= overwrite.Allow
+			deny |= overwrite.Deny
+		}
+	}
+	permissions &^= deny
+	permissions |= allow
+
+	for _, overwrite := range channel.PermissionOverwrites {
+		if overwrite.Type == "member" && overwrite.ID == userID {
+			permissions &^= overwrite.Deny
+			permissions |= overwrite.Allow
+		}
+	}
+
+	return permissions
+}
@@ -0,0 +1,125 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"github.com/andersfylling/disgord"
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router"
+	"testing"
+)
+
+const (
+	testGuildID     disgord.Snowflake = 1
+	testOwnerID     disgord.Snowflake = 2
+	testUserID      disgord.Snowflake = 3
+	testModeratorID disgord.Snowflake = 4
+)
+
+func newPermissionsGuild() (*disgord.Guild, []*disgord.Role) {
+	guild := &disgord.Guild{
+		ID:      testGuildID,
+		OwnerID: testOwnerID,
+	}
+
+	roles := []*disgord.Role{
+		{ID: testGuildID, Permissions: disgord.PermissionReadMessages | disgord.PermissionSendMessages},
+		{ID: testModeratorID, Permissions: disgord.PermissionBanMembers | disgord.PermissionManageMessages},
+	}
+
+	return guild, roles
+}
+
+func Test_computePermissions(t *testing.T) {
+	t.Run("Owner", func(t *testing.T) {
+		a := assert.New(t)
+
+		guild, roles := newPermissionsGuild()
+		a.Equal(disgord.PermissionAll, computePermissions(guild, roles, nil, testOwnerID, nil))
+	})
+
+	t.Run("Roles", func(t *testing.T) {
+		a := assert.New(t)
+
+		guild, roles := newPermissionsGuild()
+
+		permissions := computePermissions(guild, roles, nil, testUserID, nil)
+		a.Equal(disgord.PermissionReadMessages|disgord.PermissionSendMessages, permissions)
+
+		permissions = computePermissions(guild, roles, nil, testUserID, []disgord.Snowflake{testModeratorID})
+		a.Equal(disgord.PermissionBanMembers, permissions&disgord.PermissionBanMembers)
+	})
+
+	t.Run("Overwrites", func(t *testing.T) {
+		a := assert.New(t)
+
+		guild, roles := newPermissionsGuild()
+		channel := &disgord.Channel{
+			PermissionOverwrites: []disgord.PermissionOverwrite{
+				{ID: testGuildID, Type: "role", Deny: disgord.PermissionSendMessages},
+				{ID: testModeratorID, Type: "role", Allow: disgord.PermissionSendMessages, Deny: disgord.PermissionManageMessages},
+				{ID: testUserID, Type: "member", Allow: disgord.PermissionManageMessages},
+			},
+		}
+
+		permissions := computePermissions(guild, roles, channel, testUserID, nil)
+		a.Equal(disgord.PermissionReadMessages|disgord.PermissionManageMessages, permissions)
+
+		permissions = computePermissions(guild, roles, channel, testUserID, []disgord.Snowflake{testModeratorID})
+		a.Equal(disgord.PermissionSendMessages, permissions&disgord.PermissionSendMessages)
+		a.Equal(disgord.PermissionManageMessages, permissions&disgord.PermissionManageMessages)
+	})
+}
+
+func TestErrMissingPermissions_Error(t *testing.T) {
+	a := assert.New(t)
+
+	err := &ErrMissingPermissions{Missing: disgord.PermissionBanMembers | disgord.PermissionManageMessages}
+	a.Equal("You are missing the following permissions: `Ban Members`, `Manage Messages`", err.Error())
+
+	err.Bot = true
+	a.Equal("I am missing the following permissions: `Ban Members`, `Manage Messages`", err.Error())
+}
+
+type permissionsRegistrar struct{}
+
+func (c *permissionsRegistrar) Ban(_ *disgord.MessageCreate) error {
+	return nil
+}
+
+func (c *permissionsRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *permissionsRegistrar) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+func (c *permissionsRegistrar) Metadata() map[string]router.Metadata {
+	return map[string]router.Metadata{
+		"ban": {
+			Permissions: disgord.PermissionBanMembers,
+		},
+	}
+}
+
+func TestTransport_checkPermissions(t *testing.T) {
+	a := assert.New(t)
+
+	r, err := NewRouter(&disgord.Client{}, prefix, &permissionsRegistrar{})
+	if !a.NoError(err) {
+		return
+	}
+
+	// Permissions cannot be satisfied outside of a guild.
+	err = r.Handle(newMessageCreate(prefix + "ban"))
+	if a.IsType(&ErrMissingPermissions{}, err) {
+		a.False(err.(*ErrMissingPermissions).Bot)
+		a.Equal(disgord.PermissionBanMembers, err.(*ErrMissingPermissions).Missing)
+	}
+}
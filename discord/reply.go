@@ -0,0 +1,100 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"context"
+	"fmt"
+	"github.com/andersfylling/disgord"
+	"go.matthewp.io/router"
+	"reflect"
+	"strings"
+)
+
+// Response represents a value returned by a command that is sent as a reply.
+type Response interface {
+	// MessageParams returns the params used to create the reply.
+	MessageParams() (*disgord.CreateMessageParams, error)
+}
+
+// ReplySender represents something that sends the replies returned by commands.
+type ReplySender interface {
+	// SendReply sends a reply to the channel the event's message was sent in.
+	SendReply(ctx context.Context, e *disgord.MessageCreate, params *disgord.CreateMessageParams) error
+}
+
+// ReplySenderFunc is an adapter to allow the use of an ordinary function as a ReplySender.
+type ReplySenderFunc func(ctx context.Context, e *disgord.MessageCreate, params *disgord.CreateMessageParams) error
+
+// SendReply calls f(ctx, e, params).
+func (f ReplySenderFunc) SendReply(ctx context.Context, e *disgord.MessageCreate, params *disgord.CreateMessageParams) error {
+	return f(ctx, e, params)
+}
+
+// clientReplySender is the default ReplySender, it sends replies using the transport's client.
+type clientReplySender struct {
+	client Session
+}
+
+// SendReply sends a reply using the client.
+func (s *clientReplySender) SendReply(ctx context.Context, e *disgord.MessageCreate, params *disgord.CreateMessageParams) error {
+	_, err := s.client.CreateMessage(ctx, e.Message.ChannelID, params)
+	return err
+}
+
+// getMessageParams converts the replies returned by commands and the data passed
+// to Context#Reply into params, data is handled similarly to disgord's SendMsg.
+func getMessageParams(data ...interface{}) (*disgord.CreateMessageParams, error) {
+	params := &disgord.CreateMessageParams{}
+
+	var content []string
+	for _, v := range data {
+		switch t := v.(type) {
+		case nil:
+			continue
+		case *disgord.CreateMessageParams:
+			*params = *t
+		case disgord.CreateMessageParams:
+			*params = t
+		case *disgord.CreateMessageFileParams:
+			params.Files = append(params.Files, *t)
+		case disgord.CreateMessageFileParams:
+			params.Files = append(params.Files, t)
+		case *disgord.Embed:
+			params.Embed = t
+		case disgord.Embed:
+			params.Embed = &t
+		case *router.HelpMessage:
+			params.Embed = getHelpEmbed(t)
+		case Response:
+			// Check for a typed nil to prevent a panic.
+			if rv := reflect.ValueOf(t); rv.Kind() == reflect.Ptr && rv.IsNil() {
+				continue
+			}
+
+			p, err := t.MessageParams()
+			if err != nil {
+				return nil, err
+			}
+
+			if p != nil {
+				*params = *p
+			}
+		case string:
+			content = append(content, t)
+		default:
+			content = append(content, fmt.Sprint(t))
+		}
+	}
+
+	if len(content) > 0 {
+		params.Content = strings.Join(content, " ")
+	}
+
+	return params, nil
+}
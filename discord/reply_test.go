@@ -0,0 +1,146 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"context"
+	"errors"
+	"github.com/andersfylling/disgord"
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router"
+	"testing"
+)
+
+type testResponse string
+
+func (r testResponse) MessageParams() (*disgord.CreateMessageParams, error) {
+	return &disgord.CreateMessageParams{Content: string(r), Tts: true}, nil
+}
+
+type replyRegistrar struct{}
+
+func (c *replyRegistrar) Text(_ *disgord.MessageCreate) (string, error) {
+	return "hello", nil
+}
+
+func (c *replyRegistrar) Empty(_ *disgord.MessageCreate) (string, error) {
+	return "", nil
+}
+
+func (c *replyRegistrar) Embed(_ *disgord.MessageCreate) (*disgord.Embed, error) {
+	return &disgord.Embed{Title: "hello"}, nil
+}
+
+func (c *replyRegistrar) Params(_ *disgord.MessageCreate) (*disgord.CreateMessageParams, error) {
+	return &disgord.CreateMessageParams{Content: "params"}, nil
+}
+
+func (c *replyRegistrar) Response(_ *router.Context) (testResponse, error) {
+	return "response", nil
+}
+
+func (c *replyRegistrar) Fail(_ *disgord.MessageCreate) (string, error) {
+	return "ignored", errors.New("failed")
+}
+
+func (c *replyRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *replyRegistrar) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+type invalidReplyRegistrar struct{}
+
+func (c *invalidReplyRegistrar) Number(_ *disgord.MessageCreate) (int, error) {
+	return 0, nil
+}
+
+func (c *invalidReplyRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *invalidReplyRegistrar) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+func TestRouter_Handle_Reply(t *testing.T) {
+	r, err := NewRouter(&disgord.Client{}, prefix, &replyRegistrar{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var replies []*disgord.CreateMessageParams
+	r.Transport.Replies = ReplySenderFunc(func(_ context.Context, _ *disgord.MessageCreate, params *disgord.CreateMessageParams) error {
+		replies = append(replies, params)
+		return nil
+	})
+
+	tests := []struct {
+		name    string
+		content string
+		reply   *disgord.CreateMessageParams
+	}{
+		{"String", "text", &disgord.CreateMessageParams{Content: "hello"}},
+		{"EmptyString", "empty", nil},
+		{"Embed", "embed", &disgord.CreateMessageParams{Embed: &disgord.Embed{Title: "hello"}}},
+		{"CreateMessageParams", "params", &disgord.CreateMessageParams{Content: "params"}},
+		{"Response", "response", &disgord.CreateMessageParams{Content: "response", Tts: true}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			replies = nil
+			a.NoError(r.Handle(newMessageCreate(prefix + test.content)))
+
+			if test.reply == nil {
+				a.Empty(replies)
+			} else if a.Len(replies, 1) {
+				a.Equal(test.reply, replies[0])
+			}
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		a := assert.New(t)
+
+		replies = nil
+		a.IsType(&router.ErrCommandExecution{}, r.Handle(newMessageCreate(prefix+"fail")))
+		a.Empty(replies)
+	})
+
+	t.Run("InvalidReturn", func(t *testing.T) {
+		a := assert.New(t)
+
+		_, err := NewRouter(&disgord.Client{}, prefix, &invalidReplyRegistrar{})
+		a.Equal(router.ErrMethodHasNoErrorReturn, err)
+	})
+}
+
+func TestGetMessageParams(t *testing.T) {
+	a := assert.New(t)
+
+	embed := &disgord.Embed{Title: "title"}
+	params, err := getMessageParams("a", nil, 1, embed, "b")
+	if a.NoError(err) {
+		a.Equal(&disgord.CreateMessageParams{Content: "a 1 b", Embed: embed}, params)
+	}
+
+	params, err = getMessageParams(&disgord.CreateMessageParams{Content: "params"})
+	if a.NoError(err) {
+		a.Equal(&disgord.CreateMessageParams{Content: "params"}, params)
+	}
+
+	params, err = getMessageParams(&router.HelpMessage{Title: "Commands"})
+	if a.NoError(err) && a.NotNil(params.Embed) {
+		a.Equal("Commands", params.Embed.Title)
+	}
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"context"
+	"errors"
+	"github.com/andersfylling/disgord"
+	"go.matthewp.io/router"
+	"reflect"
+)
+
+var (
+	// ErrMissingClient is returned when a router is created without a Discord client.
+	ErrMissingClient = errors.New("discord: missing client")
+
+	// ErrMissingInteractionUser is returned by HandleInteraction for interactions without a user or member.
+	ErrMissingInteractionUser = errors.New("discord: interaction has no user")
+	// ErrUnsupportedInteraction is returned by HandleInteraction for interactions that are not application commands.
+	ErrUnsupportedInteraction = errors.New("discord: interaction is not supported")
+
+	// ErrNotDiscord is returned by the Context helpers when the command was not
+	// invoked by a message from Discord.
+	ErrNotDiscord = errors.New("discord: message was not received from Discord")
+
+	typeMessageCreate       = reflect.TypeOf((*disgord.MessageCreate)(nil))
+	typeEmbed               = reflect.TypeOf((*disgord.Embed)(nil))
+	typeCreateMessageParams = reflect.TypeOf((*disgord.CreateMessageParams)(nil))
+	typeIResponse           = reflect.TypeOf((*Response)(nil)).Elem()
+)
+
+func init() {
+	router.RegisterPlatform(router.Platform{
+		Event:    typeMessageCreate,
+		GetEvent: getEvent,
+		Replies:  []reflect.Type{typeEmbed, typeCreateMessageParams, typeIResponse},
+		PermissionNames: func(permissions uint64) []string {
+			return PermissionNames(permissions)
+		},
+	})
+}
+
+// Router represents a router that handles messages and interactions from Discord.
+type Router struct {
+	*router.Router
+
+	// Transport is the transport messages from Discord are handled by, it's
+	// ReplySender can be replaced before any events are handled.
+	Transport *Transport
+}
+
+// NewRouter returns a new router that handles messages received by the client.
+func NewRouter(client Session, prefix string, i router.Registrar, opts ...router.Option) (*Router, error) {
+	if c, ok := client.(*disgord.Client); client == nil || ok && c == nil {
+		return nil, ErrMissingClient
+	}
+
+	r, err := router.New(prefix, i, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Router{
+		Router:    r,
+		Transport: NewTransport(client),
+	}, nil
+}
+
+// Handle handles an incoming *disgord.MessageCreate event, see router.Router#HandleMessage.
+func (r *Router) Handle(e *disgord.MessageCreate) error {
+	return r.HandleMessage(getEventContext(e), r.Transport, NewMessage(e))
+}
+
+// Event returns the event of a message received from Discord, nil is returned
+// if the message was received by another transport.
+func Event(m router.Message) *disgord.MessageCreate {
+	if dm, ok := m.(*Message); ok {
+		return dm.Event
+	}
+
+	return nil
+}
+
+// getEvent returns the event of a message received from Discord with it's
+// context replaced by ctx, so commands receive the execution's context.
+func getEvent(ctx context.Context, m router.Message) (interface{}, bool) {
+	e := Event(m)
+	if e == nil {
+		return nil, false
+	}
+
+	if ctx != nil && e.Ctx != ctx {
+		ev := *e
+		ev.Ctx = ctx
+		e = &ev
+	}
+
+	return e, true
+}
+
+// getEventContext returns the event's context, falling back to context.Background
+// if the event does not have one.
+func getEventContext(e *disgord.MessageCreate) context.Context {
+	if e.Ctx == nil {
+		return context.Background()
+	}
+
+	return e.Ctx
+}
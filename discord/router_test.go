@@ -0,0 +1,161 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord_test
+
+import (
+	"github.com/andersfylling/disgord"
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router"
+	"go.matthewp.io/router/args"
+	"go.matthewp.io/router/discord"
+	"go.matthewp.io/router/routertest"
+	"testing"
+	"time"
+)
+
+type handleCommands struct{}
+
+func (c *handleCommands) Ping(_ *disgord.MessageCreate) (string, error) {
+	return "Pong!", nil
+}
+
+func (c *handleCommands) Echo(ctx *router.Context, message *args.RawArguments) error {
+	return ctx.Reply(message.String())
+}
+
+func (c *handleCommands) Thumbs(ctx *router.Context) error {
+	return discord.React(ctx, "👍")
+}
+
+func (c *handleCommands) Whisper(ctx *router.Context) error {
+	_, err := discord.DM(ctx, "psst")
+	return err
+}
+
+func (c *handleCommands) Purge(_ *disgord.MessageCreate) error {
+	return nil
+}
+
+func (c *handleCommands) Ban(_ *disgord.MessageCreate) error {
+	return nil
+}
+
+func (c *handleCommands) Lock(_ *disgord.MessageCreate) error {
+	return nil
+}
+
+func (c *handleCommands) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *handleCommands) Arguments() map[string][]string {
+	return map[string][]string{
+		"echo": {"message"},
+	}
+}
+
+func (c *handleCommands) Metadata() map[string]router.Metadata {
+	return map[string]router.Metadata{
+		"purge": {
+			Permissions: disgord.PermissionManageMessages,
+		},
+		"ban": {
+			Permissions: disgord.PermissionBanMembers,
+			Cooldown:    &router.Cooldown{Uses: 1, Window: time.Hour, Scope: router.BucketGuild},
+		},
+		"lock": {
+			Permissions: disgord.PermissionManageChannels,
+			Cooldown:    &router.Cooldown{Uses: 1, Window: time.Hour, Scope: router.BucketGlobal},
+		},
+	}
+}
+
+func TestRouter_Handle(t *testing.T) {
+	t.Run("Reply", func(t *testing.T) {
+		a := assert.New(t)
+
+		r, session := routertest.NewRouter(t, ".", &handleCommands{})
+
+		a.NoError(r.Handle(routertest.NewMessageCreate(".ping", nil)))
+		session.AssertSent(t, "Pong!")
+
+		a.NoError(r.Handle(routertest.NewMessageCreate(".echo hello world", nil)))
+		session.AssertSent(t, "hello world")
+		a.Equal(routertest.DefaultChannelID, session.LastMessage().ChannelID)
+	})
+
+	t.Run("Reactions", func(t *testing.T) {
+		a := assert.New(t)
+
+		r, session := routertest.NewRouter(t, ".", &handleCommands{})
+
+		a.NoError(r.Handle(routertest.NewMessageCreate(".thumbs", nil)))
+		session.AssertReacted(t, "👍")
+		session.AssertNothingSent(t)
+	})
+
+	t.Run("DM", func(t *testing.T) {
+		a := assert.New(t)
+
+		r, session := routertest.NewRouter(t, ".", &handleCommands{})
+
+		author := &disgord.User{ID: 42}
+		a.NoError(r.Handle(routertest.NewMessageCreate(".whisper", author)))
+		if session.AssertSent(t, "psst") {
+			a.Equal(author.ID, session.LastMessage().ChannelID)
+		}
+	})
+
+	t.Run("UnknownCommand", func(t *testing.T) {
+		r, session := routertest.NewRouter(t, ".", &handleCommands{})
+
+		routertest.AssertError(t, r.Handle(routertest.NewMessageCreate(".missing", nil)), &router.ErrUnknownCommand{})
+		session.AssertNothingSent(t)
+	})
+
+	t.Run("Permissions", func(t *testing.T) {
+		a := assert.New(t)
+
+		r, session := routertest.NewRouter(t, ".", &handleCommands{})
+
+		const guildID, moderatorID = 1, 2
+		session.AddGuild(&disgord.Guild{ID: guildID}, &disgord.Role{ID: guildID}, &disgord.Role{ID: moderatorID, Permissions: disgord.PermissionManageMessages})
+		session.AddChannel(&disgord.Channel{ID: routertest.DefaultChannelID, GuildID: guildID})
+		session.AddMember(guildID, &disgord.Member{User: routertest.DefaultAuthor})
+
+		err := r.Handle(routertest.NewMessage(".purge").Guild(guildID).Build())
+		routertest.AssertError(t, err, &discord.ErrMissingPermissions{})
+
+		session.AddMember(guildID, &disgord.Member{User: routertest.DefaultAuthor, Roles: []disgord.Snowflake{moderatorID}})
+		a.NoError(r.Handle(routertest.NewMessage(".purge").Guild(guildID).Build()))
+	})
+
+	t.Run("Cooldown", func(t *testing.T) {
+		a := assert.New(t)
+
+		r, session := routertest.NewRouter(t, ".", &handleCommands{})
+
+		const guildID, moderatorID = 1, 2
+		moderator := &disgord.User{ID: 43}
+		session.AddGuild(&disgord.Guild{ID: guildID}, &disgord.Role{ID: guildID}, &disgord.Role{ID: moderatorID, Permissions: disgord.PermissionAdministrator})
+		session.AddChannel(&disgord.Channel{ID: routertest.DefaultChannelID, GuildID: guildID})
+		session.AddMember(guildID, &disgord.Member{User: routertest.DefaultAuthor})
+		session.AddMember(guildID, &disgord.Member{User: moderator, Roles: []disgord.Snowflake{moderatorID}})
+
+		// Users without the permissions must not use up the guild or global buckets.
+		for _, label := range []string{".ban", ".lock"} {
+			err := r.Handle(routertest.NewMessage(label).Guild(guildID).Build())
+			routertest.AssertError(t, err, &discord.ErrMissingPermissions{})
+
+			a.NoError(r.Handle(routertest.NewMessage(label).Author(moderator).Guild(guildID).Build()))
+
+			err = r.Handle(routertest.NewMessage(label).Author(moderator).Guild(guildID).Build())
+			routertest.AssertError(t, err, &router.ErrOnCooldown{})
+		}
+	})
+}
@@ -0,0 +1,37 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+// Package discord provides the disgord adapter of the router, it receives
+// *disgord.MessageCreate events and interactions from Discord and checks the
+// restrictions that depend on Discord, such as permissions, roles and NSFW
+// channels. Importing the package registers *disgord.MessageCreate as an event
+// commands can take, see router.RegisterPlatform.
+package discord // import "go.matthewp.io/router/discord"
+
+import (
+	"context"
+	"github.com/andersfylling/disgord"
+)
+
+// Session represents the Discord API used by the router, it is implemented by
+// *disgord.Client and can be substituted with a fake session in tests, see the
+// routertest package.
+type Session interface {
+	SendMsg(ctx context.Context, channelID disgord.Snowflake, data ...interface{}) (*disgord.Message, error)
+	CreateMessage(ctx context.Context, channelID disgord.Snowflake, params *disgord.CreateMessageParams, flags ...disgord.Flag) (*disgord.Message, error)
+	CreateReaction(ctx context.Context, channelID, messageID disgord.Snowflake, emoji interface{}, flags ...disgord.Flag) error
+	CreateDM(ctx context.Context, recipientID disgord.Snowflake, flags ...disgord.Flag) (*disgord.Channel, error)
+	TriggerTypingIndicator(ctx context.Context, channelID disgord.Snowflake, flags ...disgord.Flag) error
+
+	GetGuild(ctx context.Context, id disgord.Snowflake, flags ...disgord.Flag) (*disgord.Guild, error)
+	GetGuildRoles(ctx context.Context, guildID disgord.Snowflake, flags ...disgord.Flag) ([]*disgord.Role, error)
+	GetChannel(ctx context.Context, channelID disgord.Snowflake, flags ...disgord.Flag) (*disgord.Channel, error)
+	GetMember(ctx context.Context, guildID, userID disgord.Snowflake, flags ...disgord.Flag) (*disgord.Member, error)
+	GetCurrentUser(ctx context.Context, flags ...disgord.Flag) (*disgord.User, error)
+}
+
+var _ Session = (*disgord.Client)(nil)
@@ -0,0 +1,145 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"context"
+	"fmt"
+	"github.com/andersfylling/disgord"
+	"go.matthewp.io/router"
+)
+
+// Transport represents the transport of messages received from Discord, it
+// implements router.ReplyTransport and router.CheckTransport.
+type Transport struct {
+	// Client is used to check restrictions and to send replies.
+	Client Session
+	// Replies sends the replies of commands, replies are sent using the client if nil.
+	Replies ReplySender
+}
+
+var (
+	_ router.ReplyTransport = (*Transport)(nil)
+	_ router.CheckTransport = (*Transport)(nil)
+)
+
+// NewTransport returns a new transport that uses the client.
+func NewTransport(client Session) *Transport {
+	return &Transport{
+		Client: client,
+	}
+}
+
+// Reply sends the content to the channel the message was sent in.
+func (t *Transport) Reply(ctx context.Context, m router.Message, content string) error {
+	return t.SendReply(ctx, m, content)
+}
+
+// SendReply sends a reply to the channel the message was sent in using the
+// transport's ReplySender, data is handled similarly to disgord's SendMsg and
+// may contain a Response or a *router.HelpMessage, which is sent as an embed.
+func (t *Transport) SendReply(ctx context.Context, m router.Message, data ...interface{}) error {
+	e := Event(m)
+	if e == nil {
+		return ErrNotDiscord
+	}
+
+	params, err := getMessageParams(data...)
+	if err != nil {
+		return err
+	}
+
+	return t.replies().SendReply(ctx, e, params)
+}
+
+// Check checks the command's roles, NSFW and permission restrictions, see router.CheckTransport.
+func (t *Transport) Check(ctx context.Context, m router.Message, command *router.Command) error {
+	e := Event(m)
+	if e == nil {
+		return &router.ErrUnsupportedTransport{Command: command.Name()}
+	}
+
+	restrictions := command.Restrictions()
+	inGuild := !e.Message.GuildID.IsZero()
+
+	if len(restrictions.Roles) > 0 {
+		if !inGuild {
+			return &router.ErrRestricted{Command: command.Name()}
+		}
+
+		roles, err := t.getMemberRoles(ctx, e)
+		if err != nil {
+			return err
+		}
+
+		allowed := false
+		for _, role := range roles {
+			if containsString(restrictions.Roles, role.String()) {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return &router.ErrRestricted{Command: command.Name()}
+		}
+	}
+
+	if restrictions.NSFWOnly {
+		// Direct messages are never marked as NSFW.
+		if !inGuild {
+			return &router.ErrNSFWOnly{Command: command.Name()}
+		}
+
+		channel, err := t.Client.GetChannel(ctx, e.Message.ChannelID)
+		if err != nil {
+			return fmt.Errorf("discord: failed to get channel: %v", err)
+		}
+
+		if !channel.NSFW {
+			return &router.ErrNSFWOnly{Command: command.Name()}
+		}
+	}
+
+	// Check if the user and the bot have the permissions required by the command.
+	return t.checkPermissions(ctx, e, command)
+}
+
+// replies returns the ReplySender used to send replies.
+func (t *Transport) replies() ReplySender {
+	if t.Replies != nil {
+		return t.Replies
+	}
+
+	return &clientReplySender{client: t.Client}
+}
+
+// getMemberRoles returns the roles of the message's author, using the partial
+// member on the message if it is present.
+func (t *Transport) getMemberRoles(ctx context.Context, e *disgord.MessageCreate) ([]disgord.Snowflake, error) {
+	if e.Message.Member != nil {
+		return e.Message.Member.Roles, nil
+	}
+
+	member, err := t.Client.GetMember(ctx, e.Message.GuildID, e.Message.Author.ID)
+	if err != nil {
+		return nil, fmt.Errorf("discord: failed to get member: %v", err)
+	}
+
+	return member.Roles, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,142 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package discord
+
+import (
+	"context"
+	"github.com/andersfylling/disgord"
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router"
+	"testing"
+)
+
+const prefix = "."
+
+func newMessageCreate(content string) *disgord.MessageCreate {
+	return &disgord.MessageCreate{
+		Message: &disgord.Message{
+			Content: content,
+			Author:  &disgord.User{ID: 1},
+		},
+	}
+}
+
+type transportCommands struct{}
+
+func (c *transportCommands) Staff(_ *disgord.MessageCreate) error {
+	return nil
+}
+
+func (c *transportCommands) Lewd(_ *disgord.MessageCreate) error {
+	return nil
+}
+
+func (c *transportCommands) Echo(m router.Message, text string) (string, error) {
+	return m.AuthorID() + ": " + text, nil
+}
+
+func (c *transportCommands) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *transportCommands) Arguments() map[string][]string {
+	return map[string][]string{
+		"echo": {"text"},
+	}
+}
+
+func (c *transportCommands) Metadata() map[string]router.Metadata {
+	return map[string]router.Metadata{
+		"staff": {
+			Restrictions: router.Restrictions{Roles: []string{"20"}},
+		},
+		"lewd": {
+			Restrictions: router.Restrictions{NSFWOnly: true},
+		},
+	}
+}
+
+func TestNewRouter(t *testing.T) {
+	t.Run("MissingClient", func(t *testing.T) {
+		a := assert.New(t)
+
+		r, err := NewRouter(nil, prefix, &transportCommands{})
+		a.Equal(ErrMissingClient, err)
+		a.Nil(r)
+
+		var client *disgord.Client
+		r, err = NewRouter(client, prefix, &transportCommands{})
+		a.Equal(ErrMissingClient, err)
+		a.Nil(r)
+	})
+}
+
+func TestTransport_SendReply(t *testing.T) {
+	a := assert.New(t)
+
+	r, err := NewRouter(&disgord.Client{}, prefix, &transportCommands{})
+	if !a.NoError(err) {
+		return
+	}
+
+	var replies []*disgord.CreateMessageParams
+	r.Transport.Replies = ReplySenderFunc(func(_ context.Context, _ *disgord.MessageCreate, params *disgord.CreateMessageParams) error {
+		replies = append(replies, params)
+		return nil
+	})
+
+	// Commands that take a router.Message can also be ran from Discord.
+	e := newMessageCreate(prefix + "echo hello")
+	e.Message.Author = &disgord.User{ID: 42}
+	a.NoError(r.Handle(e))
+	if a.Len(replies, 1) {
+		a.Equal("42: hello", replies[0].Content)
+	}
+
+	// Replies can only be sent to messages received from Discord.
+	a.Equal(ErrNotDiscord, r.Transport.SendReply(context.Background(), router.Message(nil), "hello"))
+}
+
+func TestTransport_Check(t *testing.T) {
+	r, err := NewRouter(&disgord.Client{}, prefix, &transportCommands{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Roles", func(t *testing.T) {
+		a := assert.New(t)
+
+		// Roles cannot be satisfied outside of a guild.
+		a.Equal(&router.ErrRestricted{Command: "staff"}, r.Handle(newMessageCreate(prefix+"staff")))
+
+		// The roles of the partial member on the message are used if it's present.
+		e := newMessageCreate(prefix + "staff")
+		e.Message.GuildID = 5
+		e.Message.Member = &disgord.Member{Roles: []disgord.Snowflake{21}}
+		a.Equal(&router.ErrRestricted{Command: "staff"}, r.Handle(e))
+
+		e.Message.Member.Roles = append(e.Message.Member.Roles, 20)
+		a.NoError(r.Handle(e))
+	})
+
+	t.Run("NSFW", func(t *testing.T) {
+		a := assert.New(t)
+
+		// Direct messages are never marked as NSFW.
+		a.Equal(&router.ErrNSFWOnly{Command: "lewd"}, r.Handle(newMessageCreate(prefix+"lewd")))
+	})
+
+	t.Run("UnsupportedTransport", func(t *testing.T) {
+		a := assert.New(t)
+
+		command := r.GetCommandByName("staff")
+		if a.NotNil(command) {
+			a.Equal(&router.ErrUnsupportedTransport{Command: "staff"}, r.Transport.Check(context.Background(), router.Message(nil), command))
+		}
+	})
+}
@@ -7,6 +7,12 @@
 
 package router
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 // ErrUnknownCommand represents an Unknown Command error.
 type ErrUnknownCommand struct {
 	Command string
@@ -49,3 +55,166 @@ type ErrCommandExecution struct {
 func (err *ErrCommandExecution) Error() string {
 	return "an unexpected error occurred while running that command. (error=" + err.err.Error() + ")"
 }
+
+// ErrCommandPanic represents a panic that was recovered during a Command Execution.
+type ErrCommandPanic struct {
+	Command *Command
+	// Value is the value that was recovered from the panic.
+	Value interface{}
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack []byte
+}
+
+func (err *ErrCommandPanic) Error() string {
+	return "an unexpected error occurred while running that command. (panic=" + fmt.Sprint(err.Value) + ")"
+}
+
+// ErrOwnerOnly represents an Owner Only error.
+type ErrOwnerOnly struct {
+	Command string
+}
+
+func (err *ErrOwnerOnly) Error() string {
+	return "`" + err.Command + "` can only be used by the bot's owners"
+}
+
+// ErrGuildOnly represents a Guild Only error.
+type ErrGuildOnly struct {
+	Command string
+}
+
+func (err *ErrGuildOnly) Error() string {
+	return "`" + err.Command + "` can only be used in a server"
+}
+
+// ErrDMOnly represents a DM Only error.
+type ErrDMOnly struct {
+	Command string
+}
+
+func (err *ErrDMOnly) Error() string {
+	return "`" + err.Command + "` can only be used in direct messages"
+}
+
+// ErrNSFWOnly represents a NSFW Only error.
+type ErrNSFWOnly struct {
+	Command string
+}
+
+func (err *ErrNSFWOnly) Error() string {
+	return "`" + err.Command + "` can only be used in NSFW channels"
+}
+
+// ErrRestricted represents a Restricted error, returned when a command is
+// used outside of it's allowed guilds, channels or roles.
+type ErrRestricted struct {
+	Command string
+}
+
+func (err *ErrRestricted) Error() string {
+	return "You are not allowed to use `" + err.Command + "` here"
+}
+
+// ErrCommandDisabled represents a Command Disabled error, returned when a
+// command has been disabled using Router#Disable.
+type ErrCommandDisabled struct {
+	Command string
+}
+
+func (err *ErrCommandDisabled) Error() string {
+	return "`" + err.Command + "` is currently disabled"
+}
+
+// ErrModuleDisabled represents a Module Disabled error, returned when a
+// command's module has been disabled in the guild using Router#DisableModule.
+type ErrModuleDisabled struct {
+	Module  string
+	Command string
+}
+
+func (err *ErrModuleDisabled) Error() string {
+	return "`" + err.Command + "` is disabled in this server"
+}
+
+// ErrOnCooldown represents an On Cooldown error.
+type ErrOnCooldown struct {
+	Command string
+	// Remaining is how long until the command can be used again.
+	Remaining time.Duration
+}
+
+func (err *ErrOnCooldown) Error() string {
+	// Round up so we never tell the user to try again in 0s.
+	remaining := err.Remaining.Truncate(time.Second)
+	if remaining < err.Remaining {
+		remaining += time.Second
+	}
+
+	return "`" + err.Command + "` is on cooldown, try again in " + remaining.String()
+}
+
+// ErrRateLimited represents a Rate Limited error, returned when a user exceeds the router's rate limit.
+type ErrRateLimited struct {
+	// Retry is how long until the user can run another command.
+	Retry time.Duration
+}
+
+func (err *ErrRateLimited) Error() string {
+	return "You are sending commands too quickly, slow down!"
+}
+
+// ErrIgnored represents an Ignored error, returned when a user is temporarily
+// ignored for repeatedly exceeding the router's rate limit.
+//
+// Replying to the user is not recommended as it defeats the purpose of ignoring them.
+type ErrIgnored struct {
+	// Until is when the user will stop being ignored.
+	Until time.Time
+}
+
+func (err *ErrIgnored) Error() string {
+	return "You are being ignored for sending commands too quickly"
+}
+
+// ErrConcurrencyLimit represents a Concurrency Limit error, returned when too
+// many executions of a command are in-flight.
+type ErrConcurrencyLimit struct {
+	Command string
+	Limit   int
+	Scope   BucketScope
+}
+
+func (err *ErrConcurrencyLimit) Error() string {
+	return "`" + err.Command + "` is already running, try again once it has finished"
+}
+
+// ErrStrict represents a Strict error, returned when commands are registered
+// in strict mode and the registrar has any problems.
+type ErrStrict struct {
+	Problems []string
+}
+
+func (err *ErrStrict) Error() string {
+	return "router: strict registration failed:\n\t" + strings.Join(err.Problems, "\n\t")
+}
+
+// ErrQueueFull represents a Queue Full error, returned when a command cannot
+// be queued because the router's worker pool is full.
+type ErrQueueFull struct {
+	Command string
+}
+
+func (err *ErrQueueFull) Error() string {
+	return "I am too busy to run `" + err.Command + "` right now, try again later"
+}
+
+// ErrUnsupportedTransport represents an Unsupported Transport error, returned when
+// a command takes a platform's event or has restrictions that the transport the
+// message was received by cannot check.
+type ErrUnsupportedTransport struct {
+	Command string
+}
+
+func (err *ErrUnsupportedTransport) Error() string {
+	return "`" + err.Command + "` cannot be used here"
+}
@@ -11,17 +11,32 @@ import (
 	"context"
 	"github.com/andersfylling/disgord"
 	"go.matthewp.io/router"
+	"go.matthewp.io/router/discord"
+	"go.matthewp.io/router/routertest"
 	"log"
 	"os"
+	"time"
 )
 
-var r *router.Router
+var r *discord.Router
 
 func main() {
+	// Run the commands locally without connecting to Discord using `go run . repl`.
+	if len(os.Args) > 1 && os.Args[1] == "repl" {
+		repl()
+		return
+	}
+
+	// Print the command manifest using `go run . manifest`, the manifest can be
+	// rendered as a command reference by routerdoc.
+	if len(os.Args) > 1 && os.Args[1] == "manifest" {
+		manifest()
+		return
+	}
+
 	var token = os.Getenv("BOT_TOKEN")
 	if token == "" {
 		panic("missing $BOT_TOKEN")
-		return
 	}
 
 	client := disgord.New(disgord.Config{
@@ -30,10 +45,20 @@ func main() {
 	})
 
 	var err error
-	r, err = router.NewRouter(client, ".", &commands{s: client})
+	r, err = discord.NewRouter(client, ".", &commands{s: client}, router.WithHelp(router.HelpConfig{
+		Embed: true,
+	}), router.WithRateLimit(router.RateLimitConfig{
+		Rate:           1,
+		Burst:          5,
+		Strikes:        3,
+		StrikeWindow:   time.Minute,
+		IgnoreDuration: 10 * time.Minute,
+		OnAbuse: func(event *router.AbuseEvent) {
+			client.Logger().Info("ignoring " + event.AuthorID + " until " + event.Until.String())
+		},
+	}))
 	if err != nil {
 		log.Panicf("failed to create a new router: %v", err)
-		return
 	}
 
 	client.On(disgord.EvtReady, clientReady)
@@ -42,6 +67,37 @@ func main() {
 	_ = client.StayConnectedUntilInterrupted(context.Background())
 }
 
+func repl() {
+	session := routertest.NewSession()
+	r, err := discord.NewRouter(session, ".", &commands{}, router.WithHelp(router.HelpConfig{
+		Embed: true,
+	}))
+	if err != nil {
+		log.Panicf("failed to create a new router: %v", err)
+	}
+
+	err = routertest.REPL(r, session, os.Stdin, os.Stdout, routertest.REPLConfig{
+		GuildID: 1,
+	})
+	if err != nil {
+		log.Panicf("failed to run the repl: %v", err)
+	}
+}
+
+func manifest() {
+	r, err := router.New(".", &commands{})
+	if err != nil {
+		log.Panicf("failed to create a new router: %v", err)
+	}
+
+	data, err := r.Manifest().JSON()
+	if err != nil {
+		log.Panicf("failed to encode the manifest: %v", err)
+	}
+
+	_, _ = os.Stdout.Write(data)
+}
+
 func clientReady(s disgord.Session, _ *disgord.Ready) {
 	err := s.UpdateStatus(&disgord.UpdateStatusPayload{
 		Game: &disgord.Activity{
@@ -83,9 +139,18 @@ func clientMessageCreate(s disgord.Session, e *disgord.MessageCreate) {
 
 	// Command Router
 	err := r.Handle(e)
+	if _, ok := err.(*router.ErrIgnored); ok {
+		s.Logger().Debug("EvtMessageCreate: author is being ignored")
+		return
+	}
+
 	if err != nil {
 		s.Logger().Debug("error while executing command: " + err.Error())
 
+		if err, ok := err.(*router.ErrCommandPanic); ok {
+			s.Logger().Error("command panicked: " + err.Error() + "\n" + string(err.Stack))
+		}
+
 		_, err := s.SendMsg(e.Ctx, e.Message.ChannelID, "<@"+e.Message.Author.ID.String()+">, "+err.Error())
 		if err != nil {
 			s.Logger().Error("failed to send message to user: " + err.Error())
@@ -101,9 +166,11 @@ type commands struct {
 
 // Any exported functions on the struct will be registered as commands
 // unless they are apart of the Registrar interface or if they do not
-// accept a *disgord.MessageCreate as the first argument.
-func (c *commands) Help(_ *disgord.MessageCreate) error {
-	return nil
+// accept a *disgord.MessageCreate, *router.Context or router.Message as the
+// first argument, optionally preceded by a context.Context for a
+// *disgord.MessageCreate or router.Message.
+func (c *commands) Ping(ctx *router.Context) error {
+	return ctx.Reply("Pong!")
 }
 
 // This method will not be registered as command, because reflection does not support
@@ -114,7 +181,7 @@ func (c *commands) test(_ *disgord.MessageCreate) error {
 
 // This method will also not be registered as a command but is still exported,
 // however it will be registered as a command if it's first argument is
-// *disgord.MessageCreate
+// *disgord.MessageCreate, *router.Context or router.Message
 func (c *commands) ThisIsNotACommand() {
 	// Do something!
 }
@@ -125,7 +192,7 @@ func (c *commands) ThisIsNotACommand2(_ interface{}) {
 
 func (c *commands) Descriptions() map[string]string {
 	return map[string]string{
-		"help": "Prints this help message",
+		"ping": "Checks if the bot is online",
 	}
 }
 
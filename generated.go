@@ -0,0 +1,163 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// GeneratedRegistrar represents a Command Registrar with dispatch and parsing
+// code generated by routergen, the router uses the generated code instead of
+// reflecting over the registrar's methods.
+//
+// Descriptions, arguments and metadata are still provided by the registrar.
+type GeneratedRegistrar interface {
+	Registrar
+	RouterCommands() []GeneratedCommand
+}
+
+// GeneratedCommand represents a command method with generated dispatch code.
+type GeneratedCommand struct {
+	// Method is the name of the registrar's method.
+	Method string
+	// Event is the type of the platform event the method takes, nil if the
+	// method takes a *router.Context or a router.Message.
+	Event reflect.Type
+	// Arguments are the method's arguments following the event or context.
+	Arguments []GeneratedArgument
+	// Call calls the method with the parsed argument values, returning the
+	// method's reply if it returns one.
+	Call func(ctx *Context, values []interface{}) (interface{}, error)
+}
+
+// GeneratedArgument represents an argument of a command method with generated parsing code.
+type GeneratedArgument struct {
+	// Type is the argument's type, used for the argument's usage.
+	Type string
+	// Raw is true if the argument receives the rest of the message, see ManualParseable.
+	Raw bool
+	// Parse parses the argument's input.
+	Parse func(input string) (interface{}, error)
+	// Format formats the argument for the command's usage, nil if the argument's
+	// type does not implement Formatter.
+	Format func(name string) string
+	// Kind is the kind of the argument's type, it's guessed using Type if zero.
+	Kind reflect.Kind
+	// Option is the type of the argument's option set by OptionTyper, zero if
+	// the argument's type does not implement OptionTyper.
+	Option int
+}
+
+// getGeneratedCommands returns the commands on a registrar with generated dispatch code.
+func (r *Router) getGeneratedCommands(registrar GeneratedRegistrar) ([]*Command, error) {
+	generated := registrar.RouterCommands()
+
+	commands := make([]*Command, 0, len(generated))
+	for _, g := range generated {
+		command, err := getGeneratedCommand(registrar, g)
+		if err != nil {
+			return nil, err
+		}
+
+		commands = append(commands, command)
+	}
+
+	return commands, nil
+}
+
+// getGeneratedCommand returns the command for a method with generated dispatch code.
+func getGeneratedCommand(registrar Registrar, g GeneratedCommand) (*Command, error) {
+	if g.Call == nil {
+		return nil, ErrCommandIsNil
+	}
+
+	command := newCommand(strings.ToLower(g.Method), nilV, signatureGenerated)
+	command.generated = g.Call
+	if g.Event != nil {
+		command.platform = getEventPlatform(g.Event)
+		if command.platform == nil {
+			return nil, fmt.Errorf("router: %s takes %s, which is not the event of a registered platform", g.Method, g.Event)
+		}
+	}
+	command.Description = registrar.Descriptions()[command.name]
+
+	metadata := getMetadata(registrar, command.name)
+	if err := command.setMetadata(metadata); err != nil {
+		return nil, err
+	}
+
+	if len(g.Arguments) < 1 {
+		return command, nil
+	}
+
+	names, ok := registrar.Arguments()[command.name]
+	if !ok {
+		return nil, fmt.Errorf("router: %s takes arguments and does not have a usage", g.Method)
+	}
+
+	if len(g.Arguments) != len(names) {
+		return nil, fmt.Errorf("router: %s's usage does not have all the arguments present", g.Method)
+	}
+
+	var usageBuilder strings.Builder
+
+	command.arguments = make([]argumentValueFn, 0, len(g.Arguments))
+	for i, argument := range g.Arguments {
+		if argument.Raw {
+			command.rawArgumentsIndex = i
+		}
+
+		var usage string
+		if argument.Format != nil {
+			usage = argument.Format(names[i])
+		} else {
+			usage = "<" + names[i] + ": " + argument.Type + ">"
+		}
+
+		kind := argument.Kind
+		if kind == reflect.Invalid {
+			kind = getGeneratedKind(argument.Type)
+		}
+
+		command.arguments = append(command.arguments, generatedArgumentValue(argument.Parse))
+		command.argumentInfo = append(command.argumentInfo, &Argument{
+			name:        names[i],
+			Description: metadata.ArgumentDescriptions[names[i]],
+			typ:         argument.Type,
+			usage:       usage,
+			kind:        kind,
+			option:      argument.Option,
+		})
+		usageBuilder.WriteString(" " + usage)
+	}
+
+	command.usage = usageBuilder.String()
+	if err := command.setOptional(g.Method, metadata.Optional); err != nil {
+		return nil, err
+	}
+
+	if err := command.setDefaults(g.Method, metadata.Defaults); err != nil {
+		return nil, err
+	}
+
+	return command, nil
+}
+
+// generatedArgumentValue returns an argument value function that uses a generated parser.
+func generatedArgumentValue(parse func(input string) (interface{}, error)) argumentValueFn {
+	return func(input string) (reflect.Value, error) {
+		v, err := parse(input)
+		if err != nil {
+			return nilV, err
+		}
+
+		return reflect.ValueOf(v), nil
+	}
+}
@@ -0,0 +1,94 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router/args"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// generatedRegistrar mirrors the code generated by routergen for banRegistrar.
+type generatedRegistrar struct {
+	banRegistrar
+
+	user string
+	days int
+}
+
+func (c *generatedRegistrar) Ban(_ *Context, user *args.UserMention, days int) error {
+	c.user, c.days = string(*user), days
+	return nil
+}
+
+func (c *generatedRegistrar) RouterCommands() []GeneratedCommand {
+	return []GeneratedCommand{
+		{
+			Method: "Ban",
+			Arguments: []GeneratedArgument{
+				{
+					Type: "*args.UserMention",
+					Parse: func(input string) (interface{}, error) {
+						v := new(args.UserMention)
+						return v, v.Parse(input)
+					},
+					Format: func(name string) string {
+						return new(args.UserMention).Format(name)
+					},
+					Option: new(args.UserMention).OptionType(),
+				},
+				{
+					Type: "int",
+					Parse: func(input string) (interface{}, error) {
+						v, err := strconv.ParseInt(input, 10, 64)
+						return int(v), err
+					},
+					Kind: reflect.Int,
+				},
+			},
+			Call: func(ctx *Context, values []interface{}) (interface{}, error) {
+				a0, _ := values[0].(*args.UserMention)
+				a1, _ := values[1].(int)
+				return nil, c.Ban(ctx, a0, a1)
+			},
+		},
+	}
+}
+
+func TestRouter_GeneratedRegistrar(t *testing.T) {
+	a := assert.New(t)
+
+	registrar := &generatedRegistrar{}
+	router, err := New(prefix, registrar)
+	if !a.NoError(err) {
+		return
+	}
+
+	reflected, err := New(prefix, &banRegistrar{})
+	if !a.NoError(err) {
+		return
+	}
+
+	// The generated command must match the command created using reflection.
+	command := router.GetCommandByName("ban")
+	expected := reflected.GetCommandByName("ban")
+	if a.NotNil(command) {
+		a.Equal(expected.Description, command.Description)
+		a.Equal(expected.Usage(), command.Usage())
+		a.Equal(expected.Arguments(), command.Arguments())
+	}
+
+	a.NoError(handle(router, newTestEvent(prefix+"ban <@1234> 7")))
+	a.Equal("1234", registrar.user)
+	a.Equal(7, registrar.days)
+
+	a.IsType(&ErrInvalidUsage{}, handle(router, newTestEvent(prefix+"ban <@1234> seven")))
+	a.IsType(&ErrMissingArguments{}, handle(router, newTestEvent(prefix+"ban <@1234>")))
+}
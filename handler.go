@@ -8,43 +8,144 @@
 package router
 
 import (
-	"github.com/andersfylling/disgord"
+	"context"
 	"reflect"
+	"runtime/debug"
 	"strings"
 	"unicode"
 )
 
-// Handle handles an incoming *disgord.MessageCreate event.
-func (r *Router) Handle(e *disgord.MessageCreate) error {
-	message := e.Message.Content
+// dispatch runs the execution, or queues it if the router has a worker pool.
+func (r *Router) dispatch(x *Execution) error {
+	if r.pool != nil {
+		return r.pool.dispatch(x)
+	}
+
+	return r.run(x)
+}
+
+// checkRateLimit checks if the author of the message is sending commands too quickly.
+func (r *Router) checkRateLimit(m Message) error {
+	if r.rateLimiter == nil {
+		return nil
+	}
+
+	return r.rateLimiter.allow(m.AuthorID())
+}
+
+// prepare finds the command for the message, checks if it can be used and parses it's arguments.
+func (r *Router) prepare(ctx context.Context, t Transport, m Message) (*Execution, error) {
+	// Check if the user is sending commands too quickly.
+	if err := r.checkRateLimit(m); err != nil {
+		return nil, err
+	}
+
+	message := m.Content()
 	label, argument := getLabelAndArgument(message)
 
 	// Find the matching command using the label.
 	command := r.GetCommandByName(label)
 	if command == nil {
-		return &ErrUnknownCommand{
+		return nil, &ErrUnknownCommand{
 			Command: label,
 		}
 	}
 
-	// Get the []string of arguments.
-	arguments := getArguments(argument)
+	return r.prepareCommand(ctx, t, m, command, func() ([]reflect.Value, string, error) {
+		// Get the argument values for the reflection method call.
+		values, err := getArgumentValues(r.Prefix, command, getArguments(argument))
+		return values, argument, err
+	})
+}
+
+// prepareCommand checks if the command can be used and gets it's argument values
+// using parse, which also returns the command's raw arguments.
+func (r *Router) prepareCommand(ctx context.Context, t Transport, m Message, command *Command, parse func() ([]reflect.Value, string, error)) (x *Execution, err error) {
+	// Prevent a panicking argument parser from crashing the event goroutine.
+	defer func() {
+		if v := recover(); v != nil {
+			x = nil
+			err = &ErrCommandPanic{
+				Command: command,
+				Value:   v,
+				Stack:   debug.Stack(),
+			}
+		}
+	}()
+
+	// Check if the command can be used by the user in this channel, the transport
+	// checks the permissions required by the command.
+	if err := r.CanRun(ctx, t, m, command); err != nil {
+		return nil, err
+	}
+
+	// Check if the command is on cooldown, only after the user is known to be
+	// able to use it so rejected users cannot use up a shared bucket.
+	if err := r.checkCooldown(m, command); err != nil {
+		return nil, err
+	}
+
+	argumentValues, argument, err := parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Execution{
+		Message:   m,
+		Transport: t,
+		Router:    r,
+		Command:   command,
+
+		parent:       ctx,
+		values:       argumentValues,
+		rawArguments: argument,
+	}, nil
+}
+
+// run runs the execution.
+func (r *Router) run(x *Execution) (err error) {
+	// Tell the transport the command has finished, even if it failed or panicked.
+	// This is deferred first so it runs after the panic has been recovered.
+	if ft, ok := x.Transport.(FinishTransport); ok {
+		defer func() {
+			if finishErr := ft.Finish(x.parent, x.Message, err); err == nil {
+				err = finishErr
+			}
+		}()
+	}
+
+	// Prevent a panicking command from crashing the goroutine.
+	defer func() {
+		if v := recover(); v != nil {
+			err = &ErrCommandPanic{
+				Command: x.Command,
+				Value:   v,
+				Stack:   debug.Stack(),
+			}
+		}
+	}()
 
-	// Get the argument values for the reflection method call.
-	argumentValues, err := getArgumentValues(r.Prefix, command, arguments)
+	// Create the execution's context, it is cancelled once the command returns.
+	// This must happen before anything else so queued executions are discarded
+	// without side effects once Shutdown has given up waiting.
+	ctx, cancel, finish, err := r.startExecution(x.parent, x.Command)
 	if err != nil {
 		return err
 	}
+	defer finish()
 
-	// Call the command handler.
-	if err := callWith(command.value, e, argumentValues...); err != nil {
-		return &ErrCommandExecution{
-			Command: command,
-			err:     err,
+	// Limit the amount of in-flight executions of the command.
+	if len(x.Command.concurrency) > 0 {
+		release, err := r.concurrency.acquire(ctx, cancel, x.Message, x.Command)
+		if err != nil {
+			return err
 		}
+		defer release()
 	}
 
-	return nil
+	// Call the command handler through the middleware chain.
+	x.Context = ctx
+	return r.execute(x)
 }
 
 func getLabelAndArgument(message string) (string, string) {
@@ -122,5 +223,72 @@ func getArgumentValues(prefix string, command *Command, arguments []string) ([]r
 		}
 	}
 
+	// Omitted optional arguments receive their default or zero value.
+	for i := len(arguments); i < len(argumentValues); i++ {
+		v, err := command.omitted(i)
+		if err != nil {
+			return nil, &ErrInvalidUsage{
+				Prefix:     prefix,
+				Command:    command.name,
+				Usage:      command.usage,
+				ArgumentID: i,
+			}
+		}
+
+		argumentValues[i] = v
+	}
+
 	return argumentValues, nil
 }
+
+// getNamedArgumentValues returns the argument values for arguments bound by name,
+// the inputs joined by spaces in the order of the command's arguments are returned
+// as the raw arguments.
+func getNamedArgumentValues(prefix string, command *Command, arguments map[string]string) ([]reflect.Value, string, error) {
+	byName := make(map[string]string, len(arguments))
+	for name, input := range arguments {
+		byName[strings.ToLower(name)] = input
+	}
+
+	values := make([]reflect.Value, len(command.arguments))
+	inputs := make([]string, 0, len(command.arguments))
+	for i, argument := range command.argumentInfo {
+		input, ok := byName[strings.ToLower(argument.name)]
+		if !ok && argument.optional {
+			v, err := command.omitted(i)
+			if err != nil {
+				return nil, "", &ErrInvalidUsage{
+					Prefix:     prefix,
+					Command:    command.name,
+					Usage:      command.usage,
+					ArgumentID: i,
+				}
+			}
+
+			values[i] = v
+			continue
+		} else if !ok {
+			return nil, "", &ErrMissingArguments{
+				Prefix:  prefix,
+				Command: command.name,
+				Usage:   command.usage,
+			}
+		}
+
+		inputs = append(inputs, input)
+
+		v, err := command.arguments[i](input)
+		if err != nil {
+			return nil, "", &ErrInvalidUsage{
+				Prefix:     prefix,
+				Command:    command.name,
+				Usage:      command.usage,
+				ArgumentID: i,
+			}
+		}
+
+		values[i] = v
+	}
+
+	return values, strings.Join(inputs, " "), nil
+}
@@ -8,12 +8,97 @@
 package router
 
 import (
+	"github.com/andersfylling/disgord"
 	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router/args"
+	"strconv"
 	"testing"
 )
 
-func TestRouter_Handle(t *testing.T) {
-	// Waiting on the patience to write the test for this.
+type benchCommands struct{}
+
+func (c *benchCommands) Ping(_ *testEvent) error {
+	return nil
+}
+
+func (c *benchCommands) Add(_ *testEvent, _ int, _ int) error {
+	return nil
+}
+
+func (c *benchCommands) Ban(_ *testEvent, _ *args.UserMention, _ *args.RawArguments) error {
+	return nil
+}
+
+func (c *benchCommands) Kick(_ *testEvent, _ *args.UserMention, _ *args.RawArguments) error {
+	return nil
+}
+
+func (c *benchCommands) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *benchCommands) Arguments() map[string][]string {
+	return map[string][]string{
+		"add":  {"a", "b"},
+		"ban":  {"user", "reason"},
+		"kick": {"user", "reason"},
+	}
+}
+
+func (c *benchCommands) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"kick": {
+			Optional: []string{"reason"},
+		},
+	}
+}
+
+func newBenchRouter(b *testing.B) *Router {
+	router, err := New(prefix, &benchCommands{})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// Pad the router with extra commands to simulate a large registrar.
+	for i := 0; i < 500; i++ {
+		command := &Command{name: "padding" + strconv.Itoa(i), rawArgumentsIndex: -1}
+		router.Commands = append(router.Commands, command)
+		router.commandsByName[command.name] = command
+	}
+
+	return router
+}
+
+type panicCommands struct{}
+
+func (c *panicCommands) Panic(_ *testEvent) error {
+	panic("oh no")
+}
+
+func (c *panicCommands) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *panicCommands) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+func TestRouter_Handle_Panic(t *testing.T) {
+	a := assert.New(t)
+
+	router, err := New(prefix, &panicCommands{})
+	if !a.NoError(err) {
+		return
+	}
+
+	err = handle(router, newTestEvent(prefix+"panic"))
+	if a.IsType(&ErrCommandPanic{}, err) {
+		err := err.(*ErrCommandPanic)
+
+		a.Equal("panic", err.Command.Name())
+		a.Equal("oh no", err.Value)
+		a.NotEmpty(err.Stack)
+	}
 }
 
 func Test_getLabelAndArgument(t *testing.T) {
@@ -71,5 +156,106 @@ func Test_getArguments(t *testing.T) {
 }
 
 func Test_getArgumentValues(t *testing.T) {
-	// This is going to be a pain in the ass :/
+	router, err := New(prefix, &benchCommands{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("NoArguments", func(t *testing.T) {
+		a := assert.New(t)
+
+		values, err := getArgumentValues(prefix, router.GetCommandByName("ping"), []string{"ignored"})
+		a.NoError(err)
+		a.Empty(values)
+	})
+
+	t.Run("Arguments", func(t *testing.T) {
+		a := assert.New(t)
+
+		values, err := getArgumentValues(prefix, router.GetCommandByName("add"), []string{"1", "2"})
+		if a.NoError(err) && a.Len(values, 2) {
+			a.Equal(1, values[0].Interface())
+			a.Equal(2, values[1].Interface())
+		}
+	})
+
+	t.Run("RawArguments", func(t *testing.T) {
+		a := assert.New(t)
+
+		values, err := getArgumentValues(prefix, router.GetCommandByName("ban"), []string{"<@1234>", "being", "rude"})
+		if a.NoError(err) && a.Len(values, 2) {
+			a.Equal(disgord.Snowflake(1234), values[0].Interface().(*args.UserMention).Snowflake())
+			a.Equal("being rude", values[1].Interface().(*args.RawArguments).String())
+		}
+	})
+
+	t.Run("OptionalArguments", func(t *testing.T) {
+		a := assert.New(t)
+
+		values, err := getArgumentValues(prefix, router.GetCommandByName("kick"), []string{"<@1234>"})
+		if a.NoError(err) && a.Len(values, 2) {
+			a.Equal(disgord.Snowflake(1234), values[0].Interface().(*args.UserMention).Snowflake())
+			a.Empty(values[1].Interface().(*args.RawArguments).String())
+		}
+
+		values, err = getArgumentValues(prefix, router.GetCommandByName("kick"), []string{"<@1234>", "being", "rude"})
+		if a.NoError(err) && a.Len(values, 2) {
+			a.Equal("being rude", values[1].Interface().(*args.RawArguments).String())
+		}
+
+		_, err = getArgumentValues(prefix, router.GetCommandByName("kick"), []string{})
+		a.Equal(&ErrMissingArguments{Prefix: prefix, Command: "kick", Usage: " <user: @user> [reason: string...]"}, err)
+	})
+
+	t.Run("MissingArguments", func(t *testing.T) {
+		a := assert.New(t)
+
+		_, err := getArgumentValues(prefix, router.GetCommandByName("add"), []string{"1"})
+		a.Equal(&ErrMissingArguments{Prefix: prefix, Command: "add", Usage: " <a: int> <b: int>"}, err)
+	})
+
+	t.Run("InvalidUsage", func(t *testing.T) {
+		a := assert.New(t)
+
+		_, err := getArgumentValues(prefix, router.GetCommandByName("add"), []string{"1", "two"})
+		a.Equal(&ErrInvalidUsage{Prefix: prefix, Command: "add", Usage: " <a: int> <b: int>", ArgumentID: 1}, err)
+	})
+}
+
+func BenchmarkRouter_GetCommandByName(b *testing.B) {
+	router := newBenchRouter(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if router.GetCommandByName("padding499") == nil {
+			b.Fatal("missing command")
+		}
+	}
+}
+
+func BenchmarkRouter_Handle(b *testing.B) {
+	benchmarks := []struct {
+		name    string
+		content string
+	}{
+		{"NoArguments", prefix + "ping"},
+		{"Arguments", prefix + "add 1 2"},
+		{"RawArguments", prefix + "ban <@!123456789012345678> spamming in every channel"},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			router := newBenchRouter(b)
+			e := newTestEvent(bm.content)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := handle(router, e); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
 }
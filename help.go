@@ -0,0 +1,339 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	defaultHelpName        = "help"
+	defaultHelpDescription = "Shows a list of commands or the usage of a command"
+	defaultHelpPageSize    = 10
+
+	// uncategorized is the category used for commands without a category.
+	uncategorized = "Other"
+
+	// The limits help pages are split by, they match Discord's limits on the
+	// length of a message and the parts of an embed.
+	maxMessageLength   = 2000
+	maxHelpLength      = 6000
+	maxHelpFields      = 25
+	maxHelpFieldLength = 1024
+)
+
+// HelpConfig represents the configuration for the built-in help command.
+type HelpConfig struct {
+	// Name is the name of the help command, defaults to "help".
+	Name string
+	// Description is the description of the help command.
+	Description string
+	// Embed replies with a *HelpMessage rather than text, it's sent as an embed
+	// by the discord package and as text by transports that cannot send it.
+	Embed bool
+	// EmbedColor is the color of the embed when Embed is enabled.
+	EmbedColor int
+	// PageSize is the maximum amount of commands listed on a single page, defaults to 10.
+	// Pages are ended early if listing another command would exceed Discord's limits.
+	PageSize int
+}
+
+// WithHelp enables the built-in help command, a help command defined by the
+// registrar will always take precedence over the built-in one.
+func WithHelp(config HelpConfig) Option {
+	return func(r *Router) {
+		if config.Name == "" {
+			config.Name = defaultHelpName
+		}
+		config.Name = strings.ToLower(config.Name)
+
+		if config.Description == "" {
+			config.Description = defaultHelpDescription
+		}
+
+		if config.PageSize < 1 {
+			config.PageSize = defaultHelpPageSize
+		}
+
+		r.help = &config
+	}
+}
+
+// HelpMessage represents a help message replied by the built-in help command when
+// HelpConfig#Embed is enabled, transports that cannot send it receive it's text.
+type HelpMessage struct {
+	Title       string
+	Description string
+	Fields      []*HelpField
+	Footer      string
+	// Color is the embed color set by HelpConfig#EmbedColor.
+	Color int
+}
+
+// HelpField represents a titled section of a help message.
+type HelpField struct {
+	Name  string
+	Value string
+}
+
+// getHelpCommand returns the *Command for the built-in help command.
+func (r *Router) getHelpCommand() *Command {
+	return &Command{
+		name:        r.help.Name,
+		Description: r.help.Description,
+
+		value: reflect.ValueOf(r.handleHelp),
+
+		signature: signatureContext,
+
+		usage: " [command|page]",
+
+		rawArgumentsIndex: -1,
+	}
+}
+
+// handleHelp handles the built-in help command, the help message is returned as
+// the command's reply so it's sent by the message's transport.
+func (r *Router) handleHelp(ctx *Context) (interface{}, error) {
+	argument := strings.TrimSpace(ctx.RawArguments)
+
+	var message *HelpMessage
+	if argument == "" {
+		message = r.helpList(ctx, 1)
+	} else if page, err := strconv.Atoi(argument); err == nil {
+		message = r.helpList(ctx, page)
+	} else {
+		command := r.GetCommandByName(strings.ToLower(argument))
+		if command == nil || command.hidden || r.CanRun(ctx, ctx.Transport, ctx.Message, command) != nil {
+			return nil, &ErrUnknownCommand{
+				Command: argument,
+			}
+		}
+
+		message = r.helpCommand(command)
+	}
+
+	if message == nil {
+		return nil, &ErrInvalidUsage{
+			Prefix:  r.Prefix,
+			Command: r.help.Name,
+			Usage:   " [command|page]",
+		}
+	}
+
+	if r.help.Embed {
+		message.Color = r.help.EmbedColor
+		return message, nil
+	}
+
+	return message.String(), nil
+}
+
+// helpCommands returns the commands listed in the help message sorted by category,
+// hidden commands and commands the author of the message cannot run are excluded.
+func (r *Router) helpCommands(ctx *Context) []*Command {
+	all := r.GetCommands()
+	commands := make([]*Command, 0, len(all))
+	for _, command := range all {
+		if command.hidden || r.CanRun(ctx, ctx.Transport, ctx.Message, command) != nil {
+			continue
+		}
+
+		commands = append(commands, command)
+	}
+
+	sort.SliceStable(commands, func(i, j int) bool {
+		a, b := commands[i].category, commands[j].category
+
+		// Uncategorized commands are always listed last.
+		if a == "" || b == "" {
+			return a != "" && b == ""
+		}
+
+		return a < b
+	})
+
+	return commands
+}
+
+// helpList returns a page of the command list, nil is returned if the page does not exist.
+func (r *Router) helpList(ctx *Context, page int) *HelpMessage {
+	pages := r.helpPages(r.helpCommands(ctx))
+	if page < 1 || page > len(pages) {
+		return nil
+	}
+
+	return r.helpListPage(pages[page-1], page, len(pages))
+}
+
+// helpPages splits the commands into pages of at most PageSize commands, a page
+// is ended early if listing another command would exceed Discord's limits.
+func (r *Router) helpPages(commands []*Command) [][]*Command {
+	// The page numbers are not known yet, so the largest possible footer is used.
+	pages := [][]*Command{nil}
+	for _, command := range commands {
+		page := pages[len(pages)-1]
+		next := append(page[:len(page):len(page)], command)
+
+		if len(page) > 0 && (len(page) == r.help.PageSize || !r.helpListPage(next, len(commands), len(commands)).fits(r.help.Embed)) {
+			pages = append(pages, []*Command{command})
+			continue
+		}
+
+		pages[len(pages)-1] = next
+	}
+
+	return pages
+}
+
+// helpListPage returns the help message listing a page of commands.
+func (r *Router) helpListPage(commands []*Command, page, pages int) *HelpMessage {
+	message := &HelpMessage{
+		Title:  "Commands",
+		Footer: "Use " + r.Prefix + r.help.Name + " <command> for more information on a command",
+	}
+
+	if pages > 1 {
+		message.Footer = "Page " + strconv.Itoa(page) + "/" + strconv.Itoa(pages) + " • " + message.Footer
+	}
+
+	var field *HelpField
+	for _, command := range commands {
+		category := command.category
+		if category == "" {
+			category = uncategorized
+		}
+
+		if field == nil || field.Name != category {
+			field = &HelpField{
+				Name: category,
+			}
+			message.Fields = append(message.Fields, field)
+		} else {
+			field.Value += "\n"
+		}
+
+		line := "`" + r.Prefix + command.name + command.usage + "`"
+		if command.Description != "" {
+			line += " - " + command.Description
+		}
+
+		// A single command must always fit in a field.
+		field.Value += truncate(line, maxHelpFieldLength)
+	}
+
+	return message
+}
+
+// helpCommand returns the detailed help message for a single command.
+func (r *Router) helpCommand(command *Command) *HelpMessage {
+	message := &HelpMessage{
+		Title:       r.Prefix + command.name + command.usage,
+		Description: command.LongDescription(),
+	}
+
+	if command.deprecated != "" {
+		message.Fields = append(message.Fields, &HelpField{
+			Name:  "Deprecated",
+			Value: command.deprecated,
+		})
+	}
+
+	if len(command.aliases) > 0 {
+		aliases := make([]string, len(command.aliases))
+		for i, alias := range command.aliases {
+			aliases[i] = "`" + r.Prefix + alias + "`"
+		}
+
+		message.Fields = append(message.Fields, &HelpField{
+			Name:  "Aliases",
+			Value: strings.Join(aliases, ", "),
+		})
+	}
+
+	if len(command.argumentInfo) > 0 {
+		arguments := make([]string, len(command.argumentInfo))
+		for i, argument := range command.argumentInfo {
+			arguments[i] = "`" + argument.usage + "`"
+			if argument.Description != "" {
+				arguments[i] += " - " + argument.Description
+			}
+		}
+
+		message.Fields = append(message.Fields, &HelpField{
+			Name:  "Arguments",
+			Value: strings.Join(arguments, "\n"),
+		})
+	}
+
+	if len(command.examples) > 0 {
+		examples := make([]string, len(command.examples))
+		for i, example := range command.examples {
+			examples[i] = "`" + r.Prefix + example + "`"
+		}
+
+		message.Fields = append(message.Fields, &HelpField{
+			Name:  "Examples",
+			Value: strings.Join(examples, "\n"),
+		})
+	}
+
+	return message
+}
+
+// fits returns true if the help message is within Discord's limits without being truncated.
+func (m *HelpMessage) fits(embed bool) bool {
+	if !embed {
+		return utf8.RuneCountInString(m.markdown()) <= maxMessageLength
+	}
+
+	if len(m.Fields) > maxHelpFields {
+		return false
+	}
+
+	length := utf8.RuneCountInString(m.Title) + utf8.RuneCountInString(m.Description) + utf8.RuneCountInString(m.Footer)
+	for _, field := range m.Fields {
+		value := utf8.RuneCountInString(field.Value)
+		if value > maxHelpFieldLength {
+			return false
+		}
+
+		length += utf8.RuneCountInString(field.Name) + value
+	}
+
+	return length <= maxHelpLength
+}
+
+// String renders the help message as markdown text, truncated to Discord's message length limit.
+func (m *HelpMessage) String() string {
+	return truncate(m.markdown(), maxMessageLength)
+}
+
+// markdown renders the help message as markdown text.
+func (m *HelpMessage) markdown() string {
+	var b strings.Builder
+
+	b.WriteString("**" + m.Title + "**")
+	if m.Description != "" {
+		b.WriteString("\n" + m.Description)
+	}
+
+	for _, field := range m.Fields {
+		b.WriteString("\n\n**" + field.Name + "**\n" + field.Value)
+	}
+
+	if m.Footer != "" {
+		b.WriteString("\n\n_" + m.Footer + "_")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,317 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router/args"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type helpRegistrar struct{}
+
+func (c *helpRegistrar) Ping(_ Message) error {
+	return nil
+}
+
+func (c *helpRegistrar) Ban(_ *testEvent, _ *args.UserMention, _ *args.RawArguments) error {
+	return nil
+}
+
+func (c *helpRegistrar) Eval(_ *testEvent) error {
+	return nil
+}
+
+func (c *helpRegistrar) Descriptions() map[string]string {
+	return map[string]string{
+		"ping": "Pong!",
+		"ban":  "Bans a user",
+	}
+}
+
+func (c *helpRegistrar) Arguments() map[string][]string {
+	return map[string][]string{
+		"ban": {"user", "reason"},
+	}
+}
+
+func (c *helpRegistrar) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"eval": {
+			Hidden: true,
+		},
+		"ping": {
+			LongDescription: "Replies with Pong! if the bot is online",
+			Deprecated:      "Use .status instead",
+		},
+		"ban": {
+			Category: "Moderation",
+			Aliases:  []string{"Hammer"},
+			Examples: []string{"ban @user spamming"},
+			ArgumentDescriptions: map[string]string{
+				"user": "The user to ban",
+			},
+		},
+	}
+}
+
+func newHelpRouter(t *testing.T, config HelpConfig) *Router {
+	router, err := New(prefix, &helpRegistrar{}, WithHelp(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return router
+}
+
+// newHelpContext returns the context of the help command for a message received by a testTransport.
+func newHelpContext(router *Router, m Message) *Context {
+	return &Context{
+		Context:   context.Background(),
+		Message:   m,
+		Transport: &testTransport{},
+		Router:    router,
+	}
+}
+
+func TestWithHelp(t *testing.T) {
+	t.Run("Registered", func(t *testing.T) {
+		a := assert.New(t)
+
+		router := newHelpRouter(t, HelpConfig{})
+
+		command := router.GetCommandByName("help")
+		if a.NotNil(command) {
+			a.Equal(defaultHelpDescription, command.Description)
+		}
+	})
+
+	t.Run("Overridden", func(t *testing.T) {
+		a := assert.New(t)
+
+		router := newHelpRouter(t, HelpConfig{Name: "Ping"})
+
+		command := router.GetCommandByName("ping")
+		if a.NotNil(command) {
+			a.Equal("Pong!", command.Description)
+		}
+		a.Len(router.Commands, 3)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		a := assert.New(t)
+
+		router, err := New(prefix, &helpRegistrar{})
+		a.NoError(err)
+		a.Nil(router.GetCommandByName("help"))
+	})
+}
+
+func TestRouter_helpList(t *testing.T) {
+	t.Run("Categories", func(t *testing.T) {
+		a := assert.New(t)
+
+		router := newHelpRouter(t, HelpConfig{})
+
+		message := router.helpList(newHelpContext(router, newTestEvent(prefix+"help")), 1)
+		if !a.NotNil(message) {
+			return
+		}
+
+		if a.Len(message.Fields, 2) {
+			a.Equal("Moderation", message.Fields[0].Name)
+			a.Equal("`.ban <user: @user> [reason: string...]` - Bans a user", message.Fields[0].Value)
+			a.Equal(uncategorized, message.Fields[1].Name)
+			a.NotContains(message.Fields[1].Value, "eval", "hidden command is listed")
+		}
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		a := assert.New(t)
+
+		router := newHelpRouter(t, HelpConfig{PageSize: 2})
+
+		message := router.helpList(newHelpContext(router, newTestEvent(prefix+"help")), 2)
+		if a.NotNil(message) {
+			a.Len(message.Fields, 1)
+			a.Contains(message.Footer, "Page 2/2")
+		}
+
+		a.Nil(router.helpList(newHelpContext(router, newTestEvent(prefix+"help")), 0))
+		a.Nil(router.helpList(newHelpContext(router, newTestEvent(prefix+"help")), 3))
+	})
+}
+
+func TestRouter_helpList_Limits(t *testing.T) {
+	for _, embed := range []bool{false, true} {
+		a := assert.New(t)
+
+		router := newHelpRouter(t, HelpConfig{Embed: embed, PageSize: 50})
+
+		// Commands with long descriptions must be spread across pages instead of
+		// exceeding Discord's limits.
+		for i := 0; i < 10; i++ {
+			command, err := NewCommand("long" + strconv.Itoa(i)).
+				Description(strings.Repeat("a", 600)).
+				Handler(func(_ *testEvent) error { return nil }).
+				Build()
+			if !a.NoError(err) {
+				return
+			}
+			a.NoError(router.RegisterCommands(command))
+		}
+
+		pages := 0
+		listed := 0
+		for page := 1; ; page++ {
+			message := router.helpList(newHelpContext(router, newTestEvent(prefix+"help")), page)
+			if message == nil {
+				break
+			}
+
+			pages++
+			a.True(message.fits(embed), "page %d does not fit", page)
+			for _, field := range message.Fields {
+				listed += strings.Count(field.Value, "`"+prefix)
+			}
+		}
+
+		a.True(pages > 1, "commands were not spread across pages")
+		a.Equal(13, listed, "every command must be listed once")
+	}
+}
+
+func TestHelpMessage_String(t *testing.T) {
+	a := assert.New(t)
+
+	message := &HelpMessage{
+		Title:       strings.Repeat("t", 300),
+		Description: strings.Repeat("d", 3000),
+		Footer:      "footer",
+	}
+	for i := 0; i < 30; i++ {
+		message.Fields = append(message.Fields, &HelpField{Name: "Field", Value: strings.Repeat("v", 2000)})
+	}
+
+	a.Len([]rune(message.String()), maxMessageLength)
+}
+
+func TestRouter_handleHelp_Reply(t *testing.T) {
+	t.Run("Text", func(t *testing.T) {
+		a := assert.New(t)
+
+		router := newHelpRouter(t, HelpConfig{})
+
+		transport := &testTransport{}
+		a.NoError(router.HandleMessage(context.Background(), transport, newTestEvent(prefix+"help")))
+		if replies := transport.Replies(); a.Len(replies, 1) && a.IsType("", replies[0]) {
+			a.Contains(replies[0], "**Commands**")
+		}
+	})
+
+	t.Run("Embed", func(t *testing.T) {
+		a := assert.New(t)
+
+		router := newHelpRouter(t, HelpConfig{Embed: true, EmbedColor: 0xff0000})
+
+		// The help message is passed to the transport so it can be sent as an embed.
+		transport := &testTransport{}
+		a.NoError(router.HandleMessage(context.Background(), transport, newTestEvent(prefix+"help ban")))
+		if replies := transport.Replies(); a.Len(replies, 1) && a.IsType(&HelpMessage{}, replies[0]) {
+			message := replies[0].(*HelpMessage)
+			a.Equal(".ban <user: @user> [reason: string...]", message.Title)
+			a.Equal(0xff0000, message.Color)
+		}
+	})
+}
+
+func TestRouter_handleHelp_Transport(t *testing.T) {
+	var replies []string
+	transport := TransportFunc(func(_ context.Context, _ Message, content string) error {
+		replies = append(replies, content)
+		return nil
+	})
+
+	router, err := New(prefix, &helpRegistrar{}, WithHelp(HelpConfig{Embed: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("List", func(t *testing.T) {
+		a := assert.New(t)
+
+		// Other transports receive text and only see the commands they can run.
+		replies = nil
+		a.NoError(router.HandleMessage(context.Background(), transport, newTestMessage(prefix+"help")))
+		if a.Len(replies, 1) {
+			a.Contains(replies[0], "**Commands**")
+			a.Contains(replies[0], "`"+prefix+"ping`")
+			a.NotContains(replies[0], "`"+prefix+"ban")
+		}
+	})
+
+	t.Run("Command", func(t *testing.T) {
+		a := assert.New(t)
+
+		replies = nil
+		a.NoError(router.HandleMessage(context.Background(), transport, newTestMessage(prefix+"help ping")))
+		if a.Len(replies, 1) {
+			a.Contains(replies[0], "Replies with Pong! if the bot is online")
+		}
+
+		err := router.HandleMessage(context.Background(), transport, newTestMessage(prefix+"help ban"))
+		if a.IsType(&ErrCommandExecution{}, err) {
+			a.IsType(&ErrUnknownCommand{}, err.(*ErrCommandExecution).err)
+		}
+	})
+}
+
+func TestRouter_helpCommand(t *testing.T) {
+	a := assert.New(t)
+
+	router := newHelpRouter(t, HelpConfig{})
+
+	command := router.GetCommandByName("hammer")
+	if !a.NotNil(command) {
+		return
+	}
+
+	message := router.helpCommand(command)
+	a.Equal(".ban <user: @user> [reason: string...]", message.Title)
+	a.Equal("Bans a user", message.Description)
+
+	if a.Len(message.Fields, 3) {
+		a.Equal("`.hammer`", message.Fields[0].Value)
+		a.Equal("`<user: @user>` - The user to ban\n`[reason: string...]`", message.Fields[1].Value)
+		a.Equal("`.ban @user spamming`", message.Fields[2].Value)
+	}
+	a.Contains(message.String(), "**Examples**\n`.ban @user spamming`")
+}
+
+func TestRouter_helpCommand_Deprecated(t *testing.T) {
+	a := assert.New(t)
+
+	router := newHelpRouter(t, HelpConfig{})
+
+	command := router.GetCommandByName("ping")
+	if !a.NotNil(command) {
+		return
+	}
+	a.True(command.IsDeprecated())
+
+	message := router.helpCommand(command)
+	a.Equal("Replies with Pong! if the bot is online", message.Description)
+
+	if a.Len(message.Fields, 1) {
+		a.Equal("Deprecated", message.Fields[0].Name)
+		a.Equal("Use .status instead", message.Fields[0].Value)
+	}
+}
@@ -0,0 +1,131 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Manifest represents a description of every command registered with a router,
+// used by external tools such as dashboards and documentation sites.
+type Manifest struct {
+	Prefix   string             `json:"prefix"`
+	Commands []*CommandManifest `json:"commands"`
+}
+
+// CommandManifest represents the description of a command.
+type CommandManifest struct {
+	Name            string              `json:"name"`
+	Aliases         []string            `json:"aliases,omitempty"`
+	Description     string              `json:"description,omitempty"`
+	LongDescription string              `json:"long_description,omitempty"`
+	Category        string              `json:"category,omitempty"`
+	Module          string              `json:"module,omitempty"`
+	Usage           string              `json:"usage"`
+	Examples        []string            `json:"examples,omitempty"`
+	Arguments       []*ArgumentManifest `json:"arguments,omitempty"`
+	Permissions     []string            `json:"permissions,omitempty"`
+	BotPermissions  []string            `json:"bot_permissions,omitempty"`
+	Cooldown        *CooldownManifest   `json:"cooldown,omitempty"`
+	Hidden          bool                `json:"hidden,omitempty"`
+	Deprecated      string              `json:"deprecated,omitempty"`
+}
+
+// ArgumentManifest represents the description of a command's argument.
+//
+// Arguments are required unless Optional is set, Default is the input parsed
+// when an optional argument is omitted. Arguments with Rest set receive the rest
+// of the message instead of a single word.
+type ArgumentManifest struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Usage       string `json:"usage"`
+	Optional    bool   `json:"optional,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Rest        bool   `json:"rest,omitempty"`
+}
+
+// CooldownManifest represents the description of a command's cooldown.
+type CooldownManifest struct {
+	Uses int `json:"uses"`
+	// Window is formatted using time.Duration's String method, e.g. "1m30s".
+	Window string `json:"window"`
+	Scope  string `json:"scope"`
+}
+
+// Manifest returns the description of the command.
+func (c *Command) Manifest() *CommandManifest {
+	manifest := &CommandManifest{
+		Name:            c.name,
+		Aliases:         c.aliases,
+		Description:     c.Description,
+		LongDescription: c.longDescription,
+		Category:        c.category,
+		Module:          c.module,
+		Usage:           c.usage,
+		Examples:        c.examples,
+		Permissions:     getPermissionNames(c.permissions),
+		BotPermissions:  getPermissionNames(c.botPermissions),
+		Hidden:          c.hidden,
+		Deprecated:      c.deprecated,
+	}
+
+	for i, argument := range c.argumentInfo {
+		manifest.Arguments = append(manifest.Arguments, &ArgumentManifest{
+			Name:        argument.name,
+			Type:        argument.typ,
+			Description: argument.Description,
+			Usage:       argument.usage,
+			Optional:    argument.optional,
+			Default:     argument.def,
+			Rest:        i == c.rawArgumentsIndex,
+		})
+	}
+
+	if c.cooldown != nil {
+		manifest.Cooldown = &CooldownManifest{
+			Uses:   c.cooldown.Uses,
+			Window: c.cooldown.Window.String(),
+			Scope:  c.cooldown.Scope.String(),
+		}
+	}
+
+	return manifest
+}
+
+// Manifest returns the description of every registered command, including
+// hidden commands, sorted by name so manifests can be compared between releases.
+func (r *Router) Manifest() *Manifest {
+	commands := r.GetCommands()
+
+	manifest := &Manifest{
+		Prefix:   r.Prefix,
+		Commands: make([]*CommandManifest, 0, len(commands)),
+	}
+	for _, command := range commands {
+		manifest.Commands = append(manifest.Commands, command.Manifest())
+	}
+
+	sort.Slice(manifest.Commands, func(i, j int) bool {
+		return manifest.Commands[i].Name < manifest.Commands[j].Name
+	})
+
+	return manifest
+}
+
+// JSON returns the manifest encoded as indented JSON.
+func (m *Manifest) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
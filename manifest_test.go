@@ -0,0 +1,140 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router/args"
+	"testing"
+	"time"
+)
+
+type manifestCommands struct{}
+
+func (c *manifestCommands) Warn(_ *testEvent, _ *args.UserMention, _ *args.RawArguments) error {
+	return nil
+}
+
+func (c *manifestCommands) Ping(_ *testEvent) (string, error) {
+	return "Pong!", nil
+}
+
+func (c *manifestCommands) Descriptions() map[string]string {
+	return map[string]string{
+		"warn": "Warns a user.",
+	}
+}
+
+func (c *manifestCommands) Arguments() map[string][]string {
+	return map[string][]string{
+		"warn": {"user", "reason"},
+	}
+}
+
+func (c *manifestCommands) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"warn": {
+			Category:    "Moderation",
+			Aliases:     []string{"W"},
+			Examples:    []string{"warn @user spamming"},
+			Permissions: permissionKickMembers | permissionBanMembers,
+			Cooldown: &Cooldown{
+				Uses:   2,
+				Window: 90 * time.Second,
+				Scope:  BucketGuild,
+			},
+			ArgumentDescriptions: map[string]string{
+				"user": "The user to warn",
+			},
+			Optional: []string{"reason"},
+			Defaults: map[string]string{
+				"reason": "No reason given",
+			},
+		},
+		"ping": {
+			Hidden: true,
+		},
+	}
+}
+
+func TestRouter_Manifest(t *testing.T) {
+	a := assert.New(t)
+
+	router, err := New(prefix, &manifestCommands{})
+	if !a.NoError(err) {
+		return
+	}
+
+	manifest := router.Manifest()
+	a.Equal(prefix, manifest.Prefix)
+	if !a.Len(manifest.Commands, 2) {
+		return
+	}
+
+	a.Equal(&CommandManifest{
+		Name:   "ping",
+		Hidden: true,
+	}, manifest.Commands[0])
+
+	a.Equal(&CommandManifest{
+		Name:        "warn",
+		Aliases:     []string{"w"},
+		Description: "Warns a user.",
+		Category:    "Moderation",
+		Usage:       " <user: @user> [reason: string...]",
+		Examples:    []string{"warn @user spamming"},
+		Arguments: []*ArgumentManifest{
+			{
+				Name:        "user",
+				Type:        "*args.UserMention",
+				Description: "The user to warn",
+				Usage:       "<user: @user>",
+			},
+			{
+				Name:     "reason",
+				Type:     "*args.RawArguments",
+				Usage:    "[reason: string...]",
+				Optional: true,
+				Default:  "No reason given",
+				Rest:     true,
+			},
+		},
+		Permissions: []string{"Kick Members", "Ban Members"},
+		Cooldown: &CooldownManifest{
+			Uses:   2,
+			Window: "1m30s",
+			Scope:  "guild",
+		},
+	}, manifest.Commands[1])
+
+	data, err := manifest.JSON()
+	if !a.NoError(err) {
+		return
+	}
+
+	a.JSONEq(`{
+		"prefix": ".",
+		"commands": [
+			{"name": "ping", "usage": "", "hidden": true},
+			{
+				"name": "warn",
+				"aliases": ["w"],
+				"description": "Warns a user.",
+				"category": "Moderation",
+				"usage": " <user: @user> [reason: string...]",
+				"examples": ["warn @user spamming"],
+				"arguments": [
+					{"name": "user", "type": "*args.UserMention", "description": "The user to warn", "usage": "<user: @user>"},
+					{"name": "reason", "type": "*args.RawArguments", "usage": "[reason: string...]", "optional": true, "default": "No reason given", "rest": true}
+				],
+				"permissions": ["Kick Members", "Ban Members"],
+				"cooldown": {"uses": 2, "window": "1m30s", "scope": "guild"}
+			}
+		]
+	}`, string(data))
+}
@@ -0,0 +1,155 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"reflect"
+)
+
+// Middleware represents a function that wraps the execution of a command,
+// next must be called to continue the execution.
+type Middleware func(x *Execution, next func() error) error
+
+// Execution represents a single execution of a command.
+type Execution struct {
+	// Message is the message that invoked the command.
+	Message Message
+	// Transport is the transport the message was received by.
+	Transport Transport
+
+	Router  *Router
+	Command *Command
+
+	// Context is the execution's context, it is cancelled when the command's
+	// timeout is reached or when the router is shut down. Middleware may replace
+	// the context, the command receives it as it's context.Context or as the
+	// context of it's event.
+	Context context.Context
+
+	// parent is the context of the message, the execution's context is derived from it.
+	parent       context.Context
+	values       []reflect.Value
+	rawArguments string
+}
+
+// Arguments returns the parsed argument values the command will be called with.
+func (x *Execution) Arguments() []interface{} {
+	arguments := make([]interface{}, len(x.values))
+	for i, v := range x.values {
+		if !v.IsValid() {
+			continue
+		}
+
+		arguments[i] = v.Interface()
+	}
+
+	return arguments
+}
+
+// Use adds middleware that runs for every command, middleware runs in the order it is added.
+// Use should not be called while the router is handling events.
+func (r *Router) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// UseCategory adds middleware that runs for every command in a category, category middleware
+// always runs after middleware added using Use.
+// UseCategory should not be called while the router is handling events.
+func (r *Router) UseCategory(category string, middleware ...Middleware) {
+	if r.categoryMiddleware == nil {
+		r.categoryMiddleware = make(map[string][]Middleware)
+	}
+
+	r.categoryMiddleware[category] = append(r.categoryMiddleware[category], middleware...)
+}
+
+// execute runs the execution through the middleware chain and calls the command.
+func (r *Router) execute(x *Execution) error {
+	chain := make([]Middleware, 0, len(r.middleware)+len(x.Command.middleware))
+	chain = append(chain, r.middleware...)
+	chain = append(chain, r.categoryMiddleware[x.Command.category]...)
+	chain = append(chain, x.Command.middleware...)
+
+	var next func(i int) error
+	next = func(i int) error {
+		if i == len(chain) {
+			return x.call()
+		}
+
+		return chain[i](x, func() error {
+			return next(i + 1)
+		})
+	}
+
+	return next(0)
+}
+
+// newContext returns the *Context passed to the command.
+func (x *Execution) newContext() *Context {
+	return &Context{
+		Context: x.Context,
+		Message: x.Message,
+
+		Transport: x.Transport,
+		Router:    x.Router,
+		Command:   x.Command,
+
+		Prefix:       x.Router.Prefix,
+		RawArguments: x.rawArguments,
+	}
+}
+
+// call calls the command handler.
+func (x *Execution) call() error {
+	var reply interface{}
+	var err error
+	switch x.Command.signature {
+	case signatureMessage:
+		reply, err = callWith(x.Command.value, x.Message, x.values...)
+	case signatureContextMessage:
+		reply, err = callWith(x.Command.value, x.Context, append([]reflect.Value{reflect.ValueOf(x.Message)}, x.values...)...)
+	case signatureContext:
+		reply, err = callWith(x.Command.value, x.newContext(), x.values...)
+	case signatureGenerated:
+		reply, err = x.Command.generated(x.newContext(), x.Arguments())
+	default:
+		// The command receives the event with the execution's context.
+		e, ok := x.Command.platform.GetEvent(x.Context, x.Message)
+		if !ok {
+			return &ErrUnsupportedTransport{Command: x.Command.name}
+		}
+
+		if x.Command.signature == signatureContextEvent {
+			reply, err = callWith(x.Command.value, x.Context, append([]reflect.Value{reflect.ValueOf(e)}, x.values...)...)
+		} else {
+			reply, err = callWith(x.Command.value, e, x.values...)
+		}
+	}
+
+	if err != nil {
+		return &ErrCommandExecution{
+			Command: x.Command,
+			err:     err,
+		}
+	}
+
+	// Send the reply returned by the command.
+	if !isEmptyReply(reply) {
+		err = sendReply(x.Context, x.Transport, x.Message, reply)
+	}
+
+	if err != nil {
+		return &ErrCommandExecution{
+			Command: x.Command,
+			err:     err,
+		}
+	}
+
+	return nil
+}
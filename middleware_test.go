@@ -0,0 +1,96 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type middlewareRegistrar struct {
+	calls []string
+}
+
+func (c *middlewareRegistrar) Add(_ *testEvent, a int, b int) error {
+	c.calls = append(c.calls, "add")
+	return nil
+}
+
+func (c *middlewareRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *middlewareRegistrar) Arguments() map[string][]string {
+	return map[string][]string{
+		"add": {"a", "b"},
+	}
+}
+
+func (c *middlewareRegistrar) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"add": {
+			Category: "Math",
+			Middleware: []Middleware{
+				func(x *Execution, next func() error) error {
+					c.calls = append(c.calls, "command")
+					return next()
+				},
+			},
+		},
+	}
+}
+
+func TestRouter_Use(t *testing.T) {
+	t.Run("Order", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &middlewareRegistrar{}
+		router, err := New(prefix, registrar)
+		if !a.NoError(err) {
+			return
+		}
+
+		router.Use(func(x *Execution, next func() error) error {
+			registrar.calls = append(registrar.calls, "router")
+
+			a.Equal("add", x.Command.Name())
+			a.Equal([]interface{}{1, 2}, x.Arguments())
+			return next()
+		})
+		router.UseCategory("Math", func(x *Execution, next func() error) error {
+			registrar.calls = append(registrar.calls, "category")
+			return next()
+		})
+		router.UseCategory("Other", func(x *Execution, next func() error) error {
+			registrar.calls = append(registrar.calls, "other")
+			return next()
+		})
+
+		a.NoError(handle(router, newTestEvent(prefix+"add 1 2")))
+		a.Equal([]string{"router", "category", "command", "add"}, registrar.calls)
+	})
+
+	t.Run("ShortCircuit", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &middlewareRegistrar{}
+		router, err := New(prefix, registrar)
+		if !a.NoError(err) {
+			return
+		}
+
+		errDenied := errors.New("denied")
+		router.Use(func(x *Execution, next func() error) error {
+			return errDenied
+		})
+
+		a.Equal(errDenied, handle(router, newTestEvent(prefix+"add 1 2")))
+		a.Empty(registrar.calls)
+	})
+}
@@ -0,0 +1,295 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"fmt"
+)
+
+// Module represents a named group of commands that can be registered on a router
+// alongside other modules.
+type Module interface {
+	Registrar
+	// Name returns the module's name, it must be unique across the router's modules.
+	Name() string
+	// Description returns a short description of the module.
+	Description() string
+}
+
+// ModuleInitializer represents a module that needs to be initialized when it is registered.
+type ModuleInitializer interface {
+	Module
+	// Init is called before the module's commands are added to the router, the
+	// module is not registered if an error is returned. Init may use the router's
+	// module APIs, but the module is not returned by GetModule until Init returns.
+	Init(r *Router) error
+}
+
+// ModuleTeardowner represents a module that needs to be torn down when it is removed.
+type ModuleTeardowner interface {
+	Module
+	// Teardown is called after the module's commands are removed from the router,
+	// either by UnregisterModule or once the router has been shut down.
+	Teardown(r *Router) error
+}
+
+// WithModules registers modules when the router is created, the registrar passed to
+// NewRouter may be nil if the router only uses modules.
+func WithModules(modules ...Module) Option {
+	return func(r *Router) {
+		r.initialModules = append(r.initialModules, modules...)
+	}
+}
+
+// RegisterModule registers the commands on a module, no commands are registered
+// if the module's name or any of it's commands' names or aliases are already registered.
+//
+// RegisterModule is safe to call while the router is handling events.
+func (r *Router) RegisterModule(module Module) error {
+	if module == nil {
+		return ErrMissingRegistrar
+	}
+
+	name := module.Name()
+
+	commands, err := r.getCommands(module)
+	if err != nil {
+		return fmt.Errorf("router: module %s: %v", name, err)
+	}
+
+	for _, command := range commands {
+		command.module = name
+	}
+
+	if err := r.checkStrict(module, commands); err != nil {
+		return err
+	}
+
+	if err := r.reserveModule(name, commands); err != nil {
+		return err
+	}
+
+	// The hooks are called without modulesMx held, the reserved name prevents the
+	// module from being registered twice in the meantime.
+	if m, ok := module.(ModuleInitializer); ok {
+		if err := m.Init(r); err != nil {
+			r.modulesMx.Lock()
+			delete(r.registering, name)
+			r.modulesMx.Unlock()
+
+			return fmt.Errorf("router: failed to initialize module %s: %v", name, err)
+		}
+	}
+
+	r.modulesMx.Lock()
+	delete(r.registering, name)
+
+	// A command may have been registered by Register since the check.
+	err = r.addCommands(commands)
+	if err == nil {
+		r.modules = append(r.modules, module)
+	}
+	r.modulesMx.Unlock()
+
+	if err != nil {
+		if m, ok := module.(ModuleTeardowner); ok {
+			_ = m.Teardown(r)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// reserveModule reserves a module's name while it's being initialized, an error
+// is returned if the name or any of the module's commands are already registered.
+func (r *Router) reserveModule(name string, commands []*Command) error {
+	r.modulesMx.Lock()
+	defer r.modulesMx.Unlock()
+
+	if _, ok := r.registering[name]; ok || r.getModule(name) != nil {
+		return fmt.Errorf("router: module %s is already registered", name)
+	}
+
+	// Check for duplicate names before the module is initialized.
+	r.commandsMx.RLock()
+	_, err := r.indexCommands(commands)
+	r.commandsMx.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	r.registering[name] = struct{}{}
+	return nil
+}
+
+// UnregisterModule removes a module and all of it's commands from the router, the
+// module's teardown hook is called once it's commands have been removed.
+//
+// UnregisterModule is safe to call while the router is handling events.
+func (r *Router) UnregisterModule(name string) error {
+	module, err := r.removeModule(name)
+	if err != nil {
+		return err
+	}
+
+	if m, ok := module.(ModuleTeardowner); ok {
+		if err := m.Teardown(r); err != nil {
+			return fmt.Errorf("router: failed to tear down module %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// removeModule removes a module and all of it's commands from the router, returning the removed module.
+func (r *Router) removeModule(name string) (Module, error) {
+	r.modulesMx.Lock()
+	defer r.modulesMx.Unlock()
+
+	module := r.getModule(name)
+	if module == nil {
+		return nil, fmt.Errorf("router: module %s is not registered", name)
+	}
+
+	r.commandsMx.Lock()
+	commands := make([]*Command, 0, len(r.Commands))
+	for _, command := range r.Commands {
+		if command.module != name {
+			commands = append(commands, command)
+		}
+	}
+
+	commandsByName := make(map[string]*Command, len(r.commandsByName))
+	for n, command := range r.commandsByName {
+		if command.module != name {
+			commandsByName[n] = command
+		} else {
+			delete(r.disabled, command.name)
+		}
+	}
+
+	r.Commands = commands
+	r.commandsByName = commandsByName
+	delete(r.disabledModules, name)
+	r.commandsMx.Unlock()
+
+	modules := make([]Module, 0, len(r.modules))
+	for _, m := range r.modules {
+		if m != module {
+			modules = append(modules, m)
+		}
+	}
+	r.modules = modules
+
+	return module, nil
+}
+
+// GetModule returns a registered module by it's name, nil is returned if the module is not registered.
+func (r *Router) GetModule(name string) Module {
+	r.modulesMx.Lock()
+	defer r.modulesMx.Unlock()
+	return r.getModule(name)
+}
+
+// GetModules returns every registered module in the order they were registered.
+func (r *Router) GetModules() []Module {
+	r.modulesMx.Lock()
+	defer r.modulesMx.Unlock()
+
+	modules := make([]Module, len(r.modules))
+	copy(modules, r.modules)
+	return modules
+}
+
+// EnableModule enables a module in a guild that it was disabled in using DisableModule.
+func (r *Router) EnableModule(name string, guild string) error {
+	return r.setModuleEnabled(name, guild, true)
+}
+
+// DisableModule disables every command of a module in a guild, HandleMessage returns
+// an *ErrModuleDisabled when the module's commands are used in the guild. guild is
+// the guild's ID as returned by Message#GuildID.
+func (r *Router) DisableModule(name string, guild string) error {
+	return r.setModuleEnabled(name, guild, false)
+}
+
+// IsModuleEnabled returns false if a module has been disabled in a guild using DisableModule.
+func (r *Router) IsModuleEnabled(name string, guild string) bool {
+	r.commandsMx.RLock()
+	defer r.commandsMx.RUnlock()
+
+	_, disabled := r.disabledModules[name][guild]
+	return !disabled
+}
+
+// checkModule returns an *ErrModuleDisabled if the command's module has been
+// disabled in the guild the message was sent in.
+func (r *Router) checkModule(m Message, command *Command) error {
+	if command.module != "" && !r.IsModuleEnabled(command.module, m.GuildID()) {
+		return &ErrModuleDisabled{Module: command.module, Command: command.name}
+	}
+
+	return nil
+}
+
+func (r *Router) setModuleEnabled(name string, guild string, enabled bool) error {
+	r.modulesMx.Lock()
+	defer r.modulesMx.Unlock()
+
+	if r.getModule(name) == nil {
+		return fmt.Errorf("router: module %s is not registered", name)
+	}
+
+	r.commandsMx.Lock()
+	defer r.commandsMx.Unlock()
+
+	guilds := r.disabledModules[name]
+	if enabled {
+		delete(guilds, guild)
+		return nil
+	}
+
+	if guilds == nil {
+		guilds = make(map[string]struct{})
+		r.disabledModules[name] = guilds
+	}
+	guilds[guild] = struct{}{}
+	return nil
+}
+
+// getModule returns a registered module by it's name, r.modulesMx must be held by the caller.
+func (r *Router) getModule(name string) Module {
+	for _, module := range r.modules {
+		if module.Name() == name {
+			return module
+		}
+	}
+
+	return nil
+}
+
+// teardownModules tears down every registered module in the reverse order they
+// were registered, the first error is returned.
+func (r *Router) teardownModules() error {
+	modules := r.GetModules()
+
+	var err error
+	for i := len(modules) - 1; i >= 0; i-- {
+		m, ok := modules[i].(ModuleTeardowner)
+		if !ok {
+			continue
+		}
+
+		if e := m.Teardown(r); e != nil && err == nil {
+			err = fmt.Errorf("router: failed to tear down module %s: %v", m.Name(), e)
+		}
+	}
+
+	return err
+}
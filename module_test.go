@@ -0,0 +1,243 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type funModule struct {
+	initialized bool
+	teardowns   int
+	initErr     error
+}
+
+func (m *funModule) Name() string {
+	return "fun"
+}
+
+func (m *funModule) Description() string {
+	return "Fun commands."
+}
+
+func (m *funModule) Init(_ *Router) error {
+	m.initialized = true
+	return m.initErr
+}
+
+func (m *funModule) Teardown(_ *Router) error {
+	m.teardowns++
+	return nil
+}
+
+func (m *funModule) Joke(_ Message) error {
+	return nil
+}
+
+func (m *funModule) Descriptions() map[string]string {
+	return map[string]string{
+		"joke": "Tells a joke.",
+	}
+}
+
+func (m *funModule) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+type moderationModule struct{}
+
+func (m *moderationModule) Name() string {
+	return "moderation"
+}
+
+func (m *moderationModule) Description() string {
+	return "Moderation commands."
+}
+
+func (m *moderationModule) Joke(_ *testEvent) error {
+	return nil
+}
+
+func (m *moderationModule) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (m *moderationModule) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+// hookModule uses the router's module APIs from it's hooks.
+type hookModule struct {
+	modules     int
+	registerErr error
+	registered  bool
+}
+
+func (m *hookModule) Name() string {
+	return "hooks"
+}
+
+func (m *hookModule) Description() string {
+	return "Uses the module APIs from it's hooks."
+}
+
+func (m *hookModule) Init(r *Router) error {
+	m.modules = len(r.GetModules())
+	m.registerErr = r.RegisterModule(m)
+	return nil
+}
+
+func (m *hookModule) Teardown(r *Router) error {
+	m.registered = r.GetModule(m.Name()) != nil
+	return r.DisableModule("fun", "1")
+}
+
+func (m *hookModule) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (m *hookModule) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+func TestRouter_RegisterModule(t *testing.T) {
+	t.Run("WithModules", func(t *testing.T) {
+		a := assert.New(t)
+
+		module := &funModule{}
+		router, err := New(prefix, nil, WithModules(module))
+		if !a.NoError(err) {
+			return
+		}
+
+		a.True(module.initialized)
+		a.Equal(module, router.GetModule("fun"))
+		a.Len(router.GetModules(), 1)
+
+		command := router.GetCommandByName("joke")
+		if a.NotNil(command) {
+			a.Equal("fun", command.Module())
+		}
+		a.NoError(handle(router, newTestEvent(prefix+"joke")))
+
+		a.NoError(router.Shutdown(context.Background()))
+		a.Equal(1, module.teardowns)
+
+		// Modules are only torn down by the first call to Shutdown.
+		a.NoError(router.Shutdown(context.Background()))
+		a.Equal(1, module.teardowns)
+	})
+
+	t.Run("Hooks", func(t *testing.T) {
+		a := assert.New(t)
+
+		module := &hookModule{}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+
+			router, err := New(prefix, &commands{}, WithModules(&funModule{}, module))
+			if !a.NoError(err) {
+				return
+			}
+
+			// The module is not registered until Init returns and can't be registered twice.
+			a.Equal(1, module.modules)
+			a.Error(module.registerErr)
+			a.Len(router.GetModules(), 2)
+
+			a.NoError(router.UnregisterModule("hooks"))
+			a.False(module.registered)
+			a.False(router.IsModuleEnabled("fun", "1"))
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the module's hooks deadlocked")
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		a := assert.New(t)
+
+		router, err := New(prefix, nil, WithModules(&funModule{}))
+		if !a.NoError(err) {
+			return
+		}
+
+		a.EqualError(router.RegisterModule(&funModule{}), "router: module fun is already registered")
+		a.EqualError(router.RegisterModule(&moderationModule{}), "router: joke is already registered by module fun")
+		a.Nil(router.GetModule("moderation"))
+
+		_, err = New(prefix, nil, WithModules(&funModule{}, &moderationModule{}))
+		a.Error(err)
+	})
+
+	t.Run("InitError", func(t *testing.T) {
+		a := assert.New(t)
+
+		router, err := New(prefix, &commands{})
+		if !a.NoError(err) {
+			return
+		}
+
+		a.Error(router.RegisterModule(&funModule{initErr: errors.New("oh no")}))
+		a.Nil(router.GetModule("fun"))
+		a.Nil(router.GetCommandByName("joke"))
+	})
+
+	t.Run("Unregister", func(t *testing.T) {
+		a := assert.New(t)
+
+		module := &funModule{}
+		router, err := New(prefix, &commands{}, WithModules(module))
+		if !a.NoError(err) {
+			return
+		}
+
+		a.NoError(router.UnregisterModule("fun"))
+		a.Equal(1, module.teardowns)
+		a.Nil(router.GetModule("fun"))
+		a.Nil(router.GetCommandByName("joke"))
+		a.Error(router.UnregisterModule("fun"))
+
+		// Another module can use the names once the module has been removed.
+		a.NoError(router.RegisterModule(&moderationModule{}))
+	})
+
+	t.Run("DisableModule", func(t *testing.T) {
+		a := assert.New(t)
+
+		router, err := New(prefix, nil, WithModules(&funModule{}))
+		if !a.NoError(err) {
+			return
+		}
+
+		a.NoError(router.DisableModule("fun", "1"))
+		a.False(router.IsModuleEnabled("fun", "1"))
+		a.IsType(&ErrModuleDisabled{}, handle(router, newGuildMessageCreate(prefix+"joke", "1")))
+		a.NoError(handle(router, newGuildMessageCreate(prefix+"joke", "2")))
+
+		// Messages from other transports are checked using their guild's ID.
+		transport := TransportFunc(func(context.Context, Message, string) error {
+			return nil
+		})
+		a.IsType(&ErrModuleDisabled{}, router.HandleMessage(context.Background(), transport, &testMessage{content: prefix + "joke", guild: "1"}))
+		a.NoError(router.HandleMessage(context.Background(), transport, &testMessage{content: prefix + "joke", guild: "2"}))
+
+		a.NoError(router.EnableModule("fun", "1"))
+		a.NoError(handle(router, newGuildMessageCreate(prefix+"joke", "1")))
+
+		a.Error(router.DisableModule("missing", "1"))
+	})
+}
@@ -0,0 +1,114 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+// Platform represents the types a chat platform adds to the router, such as the
+// events and embeds of the discord package. Platforms are registered by their
+// package using RegisterPlatform.
+type Platform struct {
+	// Event is the type of the platform's message events, commands can take an
+	// event instead of a Message, optionally preceded by a context.Context.
+	Event reflect.Type
+	// GetEvent returns the event of a message with it's context replaced by ctx,
+	// ok is false if the message was not received from the platform.
+	GetEvent func(ctx context.Context, m Message) (event interface{}, ok bool)
+	// Replies are the types of the replies commands can return in addition to
+	// strings, they are sent by the platform's ReplyTransport.
+	Replies []reflect.Type
+	// PermissionNames returns the human readable names of every permission in
+	// the platform's permission bits, used by manifests.
+	PermissionNames func(permissions uint64) []string
+}
+
+var (
+	// platforms are the registered platforms, in the order they were registered.
+	platforms   []*Platform
+	platformsMx sync.RWMutex
+)
+
+// RegisterPlatform registers a platform's types so they can be used by commands,
+// it's usually called from the init function of the platform's package.
+func RegisterPlatform(platform Platform) {
+	platformsMx.Lock()
+	defer platformsMx.Unlock()
+
+	platforms = append(platforms, &platform)
+}
+
+// getPlatforms returns every registered platform.
+func getPlatforms() []*Platform {
+	platformsMx.RLock()
+	defer platformsMx.RUnlock()
+	return platforms
+}
+
+// getEventPlatform returns the platform of an event type, nil is returned if
+// the type is not the event of a registered platform.
+func getEventPlatform(t reflect.Type) *Platform {
+	for _, platform := range getPlatforms() {
+		if platform.Event != nil && platform.Event == t {
+			return platform
+		}
+	}
+
+	return nil
+}
+
+// isPlatformReply returns true if t is the type of a reply of a registered platform.
+func isPlatformReply(t reflect.Type) bool {
+	for _, platform := range getPlatforms() {
+		for _, reply := range platform.Replies {
+			if t == reply || reply.Kind() == reflect.Interface && t.Implements(reply) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getEvent returns the event of a message from the first platform that received it.
+func getEvent(ctx context.Context, m Message) (interface{}, bool) {
+	for _, platform := range getPlatforms() {
+		if platform.GetEvent == nil {
+			continue
+		}
+
+		if event, ok := platform.GetEvent(ctx, m); ok {
+			return event, true
+		}
+	}
+
+	return nil, false
+}
+
+// getPermissionNames returns the names of the permissions in the permission bits
+// using the first platform that names them.
+func getPermissionNames(permissions uint64) []string {
+	if permissions == 0 {
+		return nil
+	}
+
+	for _, platform := range getPlatforms() {
+		if platform.PermissionNames == nil {
+			continue
+		}
+
+		if names := platform.PermissionNames(permissions); len(names) > 0 {
+			return names
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"runtime"
+	"sync"
+)
+
+const defaultWorkerQueueSize = 64
+
+// BackpressurePolicy represents what happens when a worker's queue is full.
+type BackpressurePolicy int
+
+const (
+	// BackpressureReject returns an *ErrQueueFull from HandleMessage.
+	BackpressureReject BackpressurePolicy = iota
+	// BackpressureDrop drops the new command.
+	BackpressureDrop
+	// BackpressureDropOldest drops the oldest queued command to make room for the new command.
+	BackpressureDropOldest
+)
+
+// WorkerPoolConfig represents the configuration for the router's worker pool.
+type WorkerPoolConfig struct {
+	// Workers is the amount of commands that can run at once, defaults to the amount of CPUs.
+	Workers int
+	// QueueSize is the maximum amount of queued commands per worker, defaults to 64.
+	QueueSize int
+	// Policy is what happens when a worker's queue is full.
+	Policy BackpressurePolicy
+	// OnError is called with any error returned by a queued command, including
+	// an *ErrQueueFull for commands that were dropped. m is the message that
+	// invoked the command.
+	OnError func(m Message, err error)
+}
+
+// WithWorkerPool makes HandleMessage dispatch parsed commands to a bounded pool of
+// workers instead of running them on the calling goroutine.
+//
+// Commands from the same guild, or the same channel for direct messages, are
+// always ran by the same worker in the order they were handled.
+func WithWorkerPool(config WorkerPoolConfig) Option {
+	return func(r *Router) {
+		if config.Workers < 1 {
+			config.Workers = runtime.NumCPU()
+		}
+
+		if config.QueueSize < 1 {
+			config.QueueSize = defaultWorkerQueueSize
+		}
+
+		r.poolConfig = &config
+	}
+}
+
+// workerPool represents a bounded pool of workers that run commands.
+type workerPool struct {
+	r      *Router
+	config WorkerPoolConfig
+
+	queues []chan *Execution
+	// locks make dropping the oldest command and queueing the new command atomic.
+	locks []sync.Mutex
+}
+
+func newWorkerPool(r *Router, config WorkerPoolConfig) *workerPool {
+	p := &workerPool{
+		r:      r,
+		config: config,
+
+		queues: make([]chan *Execution, config.Workers),
+		locks:  make([]sync.Mutex, config.Workers),
+	}
+
+	for i := range p.queues {
+		p.queues[i] = make(chan *Execution, config.QueueSize)
+		go p.work(p.queues[i])
+	}
+
+	return p
+}
+
+// dispatch queues an execution on the worker responsible for it's guild or channel.
+func (p *workerPool) dispatch(x *Execution) error {
+	i := int(getWorkerKey(x.Message) % uint64(len(p.queues)))
+	queue := p.queues[i]
+
+	// The execution is tracked until a worker has finished running it.
+	p.r.wg.Add(1)
+
+	select {
+	case queue <- x:
+		return nil
+	default:
+	}
+
+	switch p.config.Policy {
+	case BackpressureDrop:
+		p.drop(x)
+		return nil
+
+	case BackpressureDropOldest:
+		p.locks[i].Lock()
+		defer p.locks[i].Unlock()
+
+		for {
+			select {
+			case queue <- x:
+				return nil
+			default:
+			}
+
+			select {
+			case old := <-queue:
+				p.drop(old)
+			default:
+			}
+		}
+
+	default:
+		p.r.wg.Done()
+		return &ErrQueueFull{
+			Command: x.Command.name,
+		}
+	}
+}
+
+// drop reports an execution that was dropped because a queue was full.
+func (p *workerPool) drop(x *Execution) {
+	p.report(x, &ErrQueueFull{
+		Command: x.Command.name,
+	})
+	p.r.wg.Done()
+}
+
+// report passes an error to the OnError callback.
+func (p *workerPool) report(x *Execution, err error) {
+	if p.config.OnError != nil {
+		p.config.OnError(x.Message, err)
+	}
+}
+
+// work runs the executions on a queue until the queue is closed.
+func (p *workerPool) work(queue <-chan *Execution) {
+	for x := range queue {
+		if err := p.r.run(x); err != nil {
+			p.report(x, err)
+		}
+
+		p.r.wg.Done()
+	}
+}
+
+// close closes every queue, stopping the workers once they are empty.
+func (p *workerPool) close() {
+	for _, queue := range p.queues {
+		close(queue)
+	}
+}
+
+// getWorkerKey returns the key used to pick the worker for a message, messages
+// are keyed by their guild, or their channel for direct messages.
+func getWorkerKey(m Message) uint64 {
+	if key := m.GuildID(); key != "" {
+		return hashString(key)
+	}
+
+	return hashString(m.ChannelID())
+}
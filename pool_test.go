@@ -0,0 +1,250 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type poolRegistrar struct {
+	mx    sync.Mutex
+	order []int
+
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *poolRegistrar) Record(_ *testEvent, i int) error {
+	c.mx.Lock()
+	c.order = append(c.order, i)
+	c.mx.Unlock()
+	return nil
+}
+
+func (c *poolRegistrar) Block(_ *testEvent) error {
+	c.started <- struct{}{}
+	<-c.release
+	return nil
+}
+
+func (c *poolRegistrar) Fail(_ *testEvent) error {
+	return errors.New("failed")
+}
+
+func (c *poolRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *poolRegistrar) Arguments() map[string][]string {
+	return map[string][]string{
+		"record": {"i"},
+	}
+}
+
+func newGuildMessageCreate(content string, guild string) *testEvent {
+	e := newTestEvent(content)
+	e.guild = guild
+	return e
+}
+
+func TestRouter_Handle_WorkerPool(t *testing.T) {
+	t.Run("Ordering", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &poolRegistrar{}
+		router, err := New(prefix, registrar, WithWorkerPool(WorkerPoolConfig{
+			Workers:   4,
+			QueueSize: 100,
+		}))
+		if !a.NoError(err) {
+			return
+		}
+
+		for i := 0; i < 100; i++ {
+			a.NoError(handle(router, newGuildMessageCreate(prefix+"record "+strconv.Itoa(i), "1")))
+		}
+
+		a.NoError(router.Shutdown(context.Background()))
+		if a.Len(registrar.order, 100) {
+			for i, v := range registrar.order {
+				a.Equal(i, v)
+			}
+		}
+	})
+
+	t.Run("OnError", func(t *testing.T) {
+		a := assert.New(t)
+
+		var mx sync.Mutex
+		var errs []error
+		router, err := New(prefix, &poolRegistrar{}, WithWorkerPool(WorkerPoolConfig{
+			Workers: 1,
+			OnError: func(_ Message, err error) {
+				mx.Lock()
+				errs = append(errs, err)
+				mx.Unlock()
+			},
+		}))
+		if !a.NoError(err) {
+			return
+		}
+
+		// Parsing errors are still returned by Handle.
+		a.IsType(&ErrMissingArguments{}, handle(router, newTestEvent(prefix+"record")))
+		a.NoError(handle(router, newTestEvent(prefix+"fail")))
+
+		a.NoError(router.Shutdown(context.Background()))
+		if a.Len(errs, 1) {
+			a.IsType(&ErrCommandExecution{}, errs[0])
+		}
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &poolRegistrar{started: make(chan struct{}), release: make(chan struct{})}
+		router, err := New(prefix, registrar, WithWorkerPool(WorkerPoolConfig{
+			Workers:   1,
+			QueueSize: 1,
+			Policy:    BackpressureReject,
+		}))
+		if !a.NoError(err) {
+			return
+		}
+
+		a.NoError(handle(router, newTestEvent(prefix+"block")))
+		<-registrar.started
+
+		a.NoError(handle(router, newTestEvent(prefix+"record 1")))
+		a.IsType(&ErrQueueFull{}, handle(router, newTestEvent(prefix+"record 2")))
+
+		close(registrar.release)
+		a.NoError(router.Shutdown(context.Background()))
+		a.Equal([]int{1}, registrar.order)
+	})
+
+	t.Run("DropOldest", func(t *testing.T) {
+		a := assert.New(t)
+
+		var mx sync.Mutex
+		var dropped int
+		registrar := &poolRegistrar{started: make(chan struct{}), release: make(chan struct{})}
+		router, err := New(prefix, registrar, WithWorkerPool(WorkerPoolConfig{
+			Workers:   1,
+			QueueSize: 2,
+			Policy:    BackpressureDropOldest,
+			OnError: func(_ Message, err error) {
+				if _, ok := err.(*ErrQueueFull); ok {
+					mx.Lock()
+					dropped++
+					mx.Unlock()
+				}
+			},
+		}))
+		if !a.NoError(err) {
+			return
+		}
+
+		a.NoError(handle(router, newTestEvent(prefix+"block")))
+		<-registrar.started
+
+		for i := 0; i < 5; i++ {
+			a.NoError(handle(router, newTestEvent(prefix+"record "+strconv.Itoa(i))))
+		}
+
+		close(registrar.release)
+		a.NoError(router.Shutdown(context.Background()))
+		a.Equal([]int{3, 4}, registrar.order)
+		a.Equal(3, dropped)
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &poolRegistrar{started: make(chan struct{}), release: make(chan struct{})}
+		router, err := New(prefix, registrar, WithWorkerPool(WorkerPoolConfig{
+			Workers: 1,
+		}))
+		if !a.NoError(err) {
+			return
+		}
+
+		a.NoError(handle(router, newTestEvent(prefix+"block")))
+		<-registrar.started
+		a.NoError(handle(router, newTestEvent(prefix+"record 1")))
+
+		// Shutdown waits for the queued commands once the running command finishes.
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(registrar.release)
+		}()
+
+		a.NoError(router.Shutdown(context.Background()))
+		a.Equal([]int{1}, registrar.order)
+		a.Equal(ErrRouterClosed, handle(router, newTestEvent(prefix+"record 2")))
+	})
+
+	t.Run("ShutdownRetry", func(t *testing.T) {
+		a := assert.New(t)
+
+		registrar := &poolRegistrar{started: make(chan struct{}), release: make(chan struct{})}
+		router, err := New(prefix, registrar, WithWorkerPool(WorkerPoolConfig{
+			Workers: 1,
+		}))
+		if !a.NoError(err) {
+			return
+		}
+
+		a.NoError(handle(router, newTestEvent(prefix+"block")))
+		<-registrar.started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		a.Equal(context.DeadlineExceeded, router.Shutdown(ctx))
+
+		// Retrying and calling Shutdown again must not close the pool twice.
+		close(registrar.release)
+		a.NoError(router.Shutdown(context.Background()))
+		a.NoError(router.Shutdown(context.Background()))
+	})
+	t.Run("ShutdownAbort", func(t *testing.T) {
+		a := assert.New(t)
+
+		errs := make(chan error, 1)
+		registrar := &poolRegistrar{started: make(chan struct{}), release: make(chan struct{})}
+		router, err := New(prefix, registrar, WithWorkerPool(WorkerPoolConfig{
+			Workers: 1,
+			OnError: func(_ Message, err error) {
+				errs <- err
+			},
+		}))
+		if !a.NoError(err) {
+			return
+		}
+
+		a.NoError(handle(router, newTestEvent(prefix+"block")))
+		<-registrar.started
+		a.NoError(handle(router, newTestEvent(prefix+"record 1")))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		a.Equal(context.DeadlineExceeded, router.Shutdown(ctx))
+
+		// The queued command must be discarded once Shutdown has given up waiting.
+		close(registrar.release)
+		a.Equal(ErrRouterClosed, <-errs)
+		a.NoError(router.Shutdown(context.Background()))
+		a.Empty(registrar.order)
+	})
+}
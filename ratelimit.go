@@ -0,0 +1,180 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiterSweepInterval is how often idle users are removed from the rate limiter.
+const rateLimiterSweepInterval = time.Minute
+
+// RateLimitConfig represents the configuration for the router's per-user rate limit.
+type RateLimitConfig struct {
+	// Rate is the amount of messages a user can send per second, defaults to 1.
+	Rate float64
+	// Burst is the maximum amount of messages a user can send at once, defaults to 1.
+	Burst int
+
+	// Strikes is the amount of times a user can be rate limited within the
+	// StrikeWindow before they are temporarily ignored, zero disables ignoring.
+	Strikes int
+	// StrikeWindow is how long a strike counts against a user.
+	StrikeWindow time.Duration
+	// IgnoreDuration is how long a user is ignored for.
+	IgnoreDuration time.Duration
+
+	// OnAbuse is called when a user is ignored for repeatedly exceeding the rate limit.
+	OnAbuse func(event *AbuseEvent)
+}
+
+// AbuseEvent represents a user being ignored for exceeding the router's rate limit.
+type AbuseEvent struct {
+	// AuthorID is the ID of the user as returned by Message#AuthorID.
+	AuthorID string
+	// Strikes is the amount of times the user was rate limited.
+	Strikes int
+	// Until is when the user will stop being ignored.
+	Until time.Time
+}
+
+// WithRateLimit enables the router's per-user rate limit, the rate limit is
+// checked for every message passed to HandleMessage before the command is looked up.
+func WithRateLimit(config RateLimitConfig) Option {
+	return func(r *Router) {
+		// A rate that is not positive would never refill a user's tokens.
+		if !(config.Rate > 0) {
+			config.Rate = 1
+		}
+
+		if config.Burst < 1 {
+			config.Burst = 1
+		}
+
+		r.rateLimiter = newRateLimiter(config)
+	}
+}
+
+// rateLimiter represents a per-user token bucket rate limiter.
+type rateLimiter struct {
+	config RateLimitConfig
+
+	mx        sync.Mutex
+	users     map[string]*rateLimitedUser
+	lastSweep time.Time
+
+	now func() time.Time
+}
+
+// rateLimitedUser represents the rate limit state of a single user.
+type rateLimitedUser struct {
+	tokens float64
+	last   time.Time
+
+	strikes      int
+	strikesReset time.Time
+	ignoredUntil time.Time
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		config: config,
+
+		users: make(map[string]*rateLimitedUser),
+
+		now: time.Now,
+	}
+}
+
+// allow takes a token from the user's bucket, returning an *ErrIgnored or
+// *ErrRateLimited error if the user is not allowed to run a command.
+func (l *rateLimiter) allow(id string) error {
+	l.mx.Lock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) >= rateLimiterSweepInterval {
+		l.sweep(now)
+	}
+
+	user, ok := l.users[id]
+	if !ok {
+		user = &rateLimitedUser{
+			tokens: float64(l.config.Burst),
+			last:   now,
+		}
+		l.users[id] = user
+	}
+
+	if now.Before(user.ignoredUntil) {
+		l.mx.Unlock()
+		return &ErrIgnored{Until: user.ignoredUntil}
+	}
+
+	// Refill the bucket based on the time since the last message.
+	user.tokens += now.Sub(user.last).Seconds() * l.config.Rate
+	if user.tokens > float64(l.config.Burst) {
+		user.tokens = float64(l.config.Burst)
+	}
+	user.last = now
+
+	if user.tokens >= 1 {
+		user.tokens--
+		l.mx.Unlock()
+		return nil
+	}
+
+	retry := time.Duration((1 - user.tokens) / l.config.Rate * float64(time.Second))
+
+	if l.config.Strikes < 1 {
+		l.mx.Unlock()
+		return &ErrRateLimited{Retry: retry}
+	}
+
+	if !now.Before(user.strikesReset) {
+		user.strikes = 0
+		user.strikesReset = now.Add(l.config.StrikeWindow)
+	}
+	user.strikes++
+
+	if user.strikes < l.config.Strikes {
+		l.mx.Unlock()
+		return &ErrRateLimited{Retry: retry}
+	}
+
+	user.ignoredUntil = now.Add(l.config.IgnoreDuration)
+	event := &AbuseEvent{
+		AuthorID: id,
+		Strikes:  user.strikes,
+		Until:    user.ignoredUntil,
+	}
+	user.strikes = 0
+	l.mx.Unlock()
+
+	// The callback is called without holding the lock so it can take it's time.
+	if l.config.OnAbuse != nil {
+		l.config.OnAbuse(event)
+	}
+
+	return &ErrIgnored{Until: event.Until}
+}
+
+// sweep removes users with a full bucket that are not ignored, the lock must be held by the caller.
+func (l *rateLimiter) sweep(now time.Time) {
+	for id, user := range l.users {
+		if now.Before(user.ignoredUntil) || now.Before(user.strikesReset) {
+			continue
+		}
+
+		if user.tokens+now.Sub(user.last).Seconds()*l.config.Rate >= float64(l.config.Burst) {
+			delete(l.users, id)
+		}
+	}
+
+	l.lastSweep = now
+}
@@ -0,0 +1,120 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_rateLimiter_allow(t *testing.T) {
+	t.Run("Burst", func(t *testing.T) {
+		a := assert.New(t)
+
+		now := time.Now()
+		limiter := newRateLimiter(RateLimitConfig{Rate: 1, Burst: 2})
+		limiter.now = func() time.Time {
+			return now
+		}
+
+		a.NoError(limiter.allow("1"))
+		a.NoError(limiter.allow("1"))
+
+		err := limiter.allow("1")
+		if a.IsType(&ErrRateLimited{}, err) {
+			a.Equal(time.Second, err.(*ErrRateLimited).Retry)
+		}
+
+		// Other users are not affected.
+		a.NoError(limiter.allow("2"))
+
+		// A token is added back every second.
+		now = now.Add(time.Second)
+		a.NoError(limiter.allow("1"))
+		a.Error(limiter.allow("1"))
+	})
+
+	t.Run("Ignore", func(t *testing.T) {
+		a := assert.New(t)
+
+		var events []*AbuseEvent
+
+		now := time.Now()
+		limiter := newRateLimiter(RateLimitConfig{
+			Rate:           1,
+			Burst:          1,
+			Strikes:        2,
+			StrikeWindow:   time.Minute,
+			IgnoreDuration: time.Hour,
+			OnAbuse: func(event *AbuseEvent) {
+				events = append(events, event)
+			},
+		})
+		limiter.now = func() time.Time {
+			return now
+		}
+
+		a.NoError(limiter.allow("1"))
+		a.IsType(&ErrRateLimited{}, limiter.allow("1"))
+		a.IsType(&ErrIgnored{}, limiter.allow("1"))
+
+		if a.Len(events, 1) {
+			a.Equal("1", events[0].AuthorID)
+			a.Equal(2, events[0].Strikes)
+			a.Equal(now.Add(time.Hour), events[0].Until)
+		}
+
+		// The user stays ignored even after their bucket refills.
+		now = now.Add(time.Minute)
+		a.IsType(&ErrIgnored{}, limiter.allow("1"))
+
+		now = now.Add(time.Hour)
+		a.NoError(limiter.allow("1"))
+	})
+
+	t.Run("Sweep", func(t *testing.T) {
+		a := assert.New(t)
+
+		now := time.Now()
+		limiter := newRateLimiter(RateLimitConfig{Rate: 1, Burst: 1})
+		limiter.now = func() time.Time {
+			return now
+		}
+
+		a.NoError(limiter.allow("1"))
+		a.NoError(limiter.allow("2"))
+		a.Len(limiter.users, 2)
+
+		now = now.Add(rateLimiterSweepInterval)
+		a.NoError(limiter.allow("3"))
+		a.Len(limiter.users, 1)
+	})
+}
+
+func TestWithRateLimit(t *testing.T) {
+	a := assert.New(t)
+
+	for _, rate := range []float64{0, -1} {
+		router, err := New(prefix, cmds, WithRateLimit(RateLimitConfig{Rate: rate}))
+		if !a.NoError(err) {
+			return
+		}
+
+		// A rate that is not positive is clamped instead of dividing by zero.
+		a.Equal(1.0, router.rateLimiter.config.Rate)
+		a.Equal(1, router.rateLimiter.config.Burst)
+
+		a.NoError(router.rateLimiter.allow("1"))
+		err = router.rateLimiter.allow("1")
+		if a.IsType(&ErrRateLimited{}, err) {
+			retry := err.(*ErrRateLimited).Retry
+			a.True(retry > 0 && retry <= time.Second, "retry %v", retry)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"html"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// referenceCategory represents a category of the command reference.
+type referenceCategory struct {
+	name     string
+	commands []*CommandManifest
+}
+
+// Markdown writes the command reference to w as Markdown, commands are grouped
+// by category and hidden commands are excluded.
+func (m *Manifest) Markdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# Commands\n")
+
+	for _, category := range m.categories() {
+		b.WriteString("\n## " + category.name + "\n")
+
+		for _, command := range category.commands {
+			b.WriteString("\n### " + m.Prefix + command.Name + "\n\n")
+
+			if command.Deprecated != "" {
+				b.WriteString("> **Deprecated:** " + command.Deprecated + "\n\n")
+			}
+
+			if description := getReferenceDescription(command); description != "" {
+				b.WriteString(description + "\n\n")
+			}
+
+			b.WriteString("**Usage:** `" + m.Prefix + command.Name + command.Usage + "`\n")
+
+			if len(command.Aliases) > 0 {
+				aliases := make([]string, len(command.Aliases))
+				for i, alias := range command.Aliases {
+					aliases[i] = "`" + m.Prefix + alias + "`"
+				}
+
+				b.WriteString("\n**Aliases:** " + strings.Join(aliases, ", ") + "\n")
+			}
+
+			if len(command.Arguments) > 0 {
+				b.WriteString("\n**Arguments:**\n\n")
+				for _, argument := range command.Arguments {
+					b.WriteString("- `" + argument.Usage + "`")
+					if argument.Description != "" {
+						b.WriteString(" - " + argument.Description)
+					}
+					b.WriteString("\n")
+				}
+			}
+
+			if len(command.Permissions) > 0 {
+				b.WriteString("\n**Permissions:** " + strings.Join(command.Permissions, ", ") + "\n")
+			}
+
+			if len(command.BotPermissions) > 0 {
+				b.WriteString("\n**Bot Permissions:** " + strings.Join(command.BotPermissions, ", ") + "\n")
+			}
+
+			if command.Cooldown != nil {
+				b.WriteString("\n**Cooldown:** " + command.Cooldown.text() + "\n")
+			}
+
+			if len(command.Examples) > 0 {
+				b.WriteString("\n**Examples:**\n\n```\n")
+				for _, example := range command.Examples {
+					b.WriteString(m.Prefix + example + "\n")
+				}
+				b.WriteString("```\n")
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// HTML writes the command reference to w as an HTML document, commands are
+// grouped by category and hidden commands are excluded.
+func (m *Manifest) HTML(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Commands</title>\n</head>\n<body>\n")
+	b.WriteString("<h1>Commands</h1>\n")
+
+	for _, category := range m.categories() {
+		b.WriteString("<h2>" + html.EscapeString(category.name) + "</h2>\n")
+
+		for _, command := range category.commands {
+			b.WriteString("<section id=\"" + html.EscapeString(command.Name) + "\">\n")
+			b.WriteString("<h3>" + html.EscapeString(m.Prefix+command.Name) + "</h3>\n")
+
+			if command.Deprecated != "" {
+				b.WriteString("<p><strong>Deprecated:</strong> " + html.EscapeString(command.Deprecated) + "</p>\n")
+			}
+
+			if description := getReferenceDescription(command); description != "" {
+				b.WriteString("<p>" + html.EscapeString(description) + "</p>\n")
+			}
+
+			b.WriteString("<p><strong>Usage:</strong> <code>" + html.EscapeString(m.Prefix+command.Name+command.Usage) + "</code></p>\n")
+
+			if len(command.Aliases) > 0 {
+				aliases := make([]string, len(command.Aliases))
+				for i, alias := range command.Aliases {
+					aliases[i] = "<code>" + html.EscapeString(m.Prefix+alias) + "</code>"
+				}
+
+				b.WriteString("<p><strong>Aliases:</strong> " + strings.Join(aliases, ", ") + "</p>\n")
+			}
+
+			if len(command.Arguments) > 0 {
+				b.WriteString("<p><strong>Arguments:</strong></p>\n<ul>\n")
+				for _, argument := range command.Arguments {
+					b.WriteString("<li><code>" + html.EscapeString(argument.Usage) + "</code>")
+					if argument.Description != "" {
+						b.WriteString(" - " + html.EscapeString(argument.Description))
+					}
+					b.WriteString("</li>\n")
+				}
+				b.WriteString("</ul>\n")
+			}
+
+			if len(command.Permissions) > 0 {
+				b.WriteString("<p><strong>Permissions:</strong> " + html.EscapeString(strings.Join(command.Permissions, ", ")) + "</p>\n")
+			}
+
+			if len(command.BotPermissions) > 0 {
+				b.WriteString("<p><strong>Bot Permissions:</strong> " + html.EscapeString(strings.Join(command.BotPermissions, ", ")) + "</p>\n")
+			}
+
+			if command.Cooldown != nil {
+				b.WriteString("<p><strong>Cooldown:</strong> " + html.EscapeString(command.Cooldown.text()) + "</p>\n")
+			}
+
+			if len(command.Examples) > 0 {
+				b.WriteString("<p><strong>Examples:</strong></p>\n<pre><code>")
+				for _, example := range command.Examples {
+					b.WriteString(html.EscapeString(m.Prefix+example) + "\n")
+				}
+				b.WriteString("</code></pre>\n")
+			}
+
+			b.WriteString("</section>\n")
+		}
+	}
+
+	b.WriteString("</body>\n</html>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// categories returns the commands of the reference grouped by category, sorted
+// like the help command with uncategorized commands last.
+func (m *Manifest) categories() []*referenceCategory {
+	var categories []*referenceCategory
+	byName := map[string]*referenceCategory{}
+	for _, command := range m.Commands {
+		if command.Hidden {
+			continue
+		}
+
+		name := command.Category
+		if name == "" {
+			name = uncategorized
+		}
+
+		category, ok := byName[name]
+		if !ok {
+			category = &referenceCategory{name: name}
+			byName[name] = category
+			categories = append(categories, category)
+		}
+
+		category.commands = append(category.commands, command)
+	}
+
+	sort.SliceStable(categories, func(i, j int) bool {
+		a, b := categories[i].name, categories[j].name
+
+		// Uncategorized commands are always listed last.
+		if a == uncategorized || b == uncategorized {
+			return a != uncategorized && b == uncategorized
+		}
+
+		return a < b
+	})
+
+	return categories
+}
+
+// getReferenceDescription returns the command's long description, falling back
+// to it's description.
+func getReferenceDescription(command *CommandManifest) string {
+	if command.LongDescription != "" {
+		return command.LongDescription
+	}
+
+	return command.Description
+}
+
+// text returns a human readable description of the cooldown, e.g. "2 uses every 1m30s per user".
+func (c *CooldownManifest) text() string {
+	uses := strconv.Itoa(c.Uses) + " uses"
+	if c.Uses == 1 {
+		uses = "1 use"
+	}
+
+	switch c.Scope {
+	case "global":
+		return uses + " every " + c.Window
+	default:
+		return uses + " every " + c.Window + " per " + c.Scope
+	}
+}
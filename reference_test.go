@@ -0,0 +1,93 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"bytes"
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+var referenceManifest = &Manifest{
+	Prefix: "!",
+	Commands: []*CommandManifest{
+		{
+			Name:        "ping",
+			Description: "Checks if the bot is online",
+		},
+		{
+			Name:        "roll",
+			Description: "Rolls a die",
+			Category:    "Fun",
+			Usage:       " <sides: int>",
+			Arguments: []*ArgumentManifest{
+				{Name: "sides", Type: "int", Usage: "<sides: int>"},
+			},
+			Cooldown: &CooldownManifest{Uses: 1, Window: "5s", Scope: "global"},
+			Examples: []string{"roll 20"},
+		},
+		{
+			Name:   "secret",
+			Hidden: true,
+		},
+		{
+			Name:            "warn",
+			Aliases:         []string{"w"},
+			Description:     "Warns a user.",
+			LongDescription: "Warns a user & records the <reason>.",
+			Category:        "Moderation",
+			Usage:           " <user: @user> [reason: string...]",
+			Examples:        []string{"warn @user spamming"},
+			Arguments: []*ArgumentManifest{
+				{Name: "user", Type: "*args.UserMention", Description: "The user to warn", Usage: "<user: @user>"},
+				{Name: "reason", Type: "*args.RawArguments", Usage: "[reason: string...]", Rest: true},
+			},
+			Permissions:    []string{"Kick Members"},
+			BotPermissions: []string{"Send Messages"},
+			Cooldown:       &CooldownManifest{Uses: 2, Window: "1m30s", Scope: "guild"},
+			Deprecated:     "use !moderate instead",
+		},
+	},
+}
+
+func TestManifest_Markdown(t *testing.T) {
+	testReference(t, "commands.md", referenceManifest.Markdown)
+}
+
+func TestManifest_HTML(t *testing.T) {
+	testReference(t, "commands.html", referenceManifest.HTML)
+}
+
+// testReference compares the rendered command reference to a golden file.
+func testReference(t *testing.T, name string, render func(w io.Writer) error) {
+	a := assert.New(t)
+
+	var b bytes.Buffer
+	if !a.NoError(render(&b)) {
+		return
+	}
+
+	path := filepath.Join("testdata", "reference", name)
+	if *update {
+		a.NoError(ioutil.WriteFile(path, b.Bytes(), 0644))
+		return
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if !a.NoError(err) {
+		return
+	}
+
+	a.Equal(string(expected), b.String())
+}
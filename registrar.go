@@ -9,6 +9,7 @@ package router
 
 import (
 	"reflect"
+	"time"
 )
 
 // Registrar represents a Command Registrar.
@@ -17,6 +18,66 @@ type Registrar interface {
 	Arguments() map[string][]string
 }
 
+// MetadataRegistrar represents a Command Registrar that provides additional command metadata.
+type MetadataRegistrar interface {
+	Registrar
+	Metadata() map[string]Metadata
+}
+
+// Choice represents a choice of an argument's option, Value is either a string
+// or a number depending on the option's type.
+type Choice struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// Metadata represents additional metadata for a command.
+type Metadata struct {
+	// Category is used to group the command in the help message.
+	Category string
+	// LongDescription is shown instead of the command's description when viewing
+	// the command's help message.
+	LongDescription string
+	// Aliases are alternative names the command can be invoked by.
+	Aliases []string
+	// Examples are example invocations of the command, without the prefix.
+	Examples []string
+	// ArgumentDescriptions maps an argument's name to it's description.
+	ArgumentDescriptions map[string]string
+	// Optional are the names of the arguments that may be omitted, they must come
+	// after every required argument. Omitted arguments receive their zero value,
+	// pointers to parseable types such as *args.RawArguments receive an empty value.
+	Optional []string
+	// Defaults maps an optional argument's name to the input it is parsed from
+	// when the argument is omitted, instead of receiving it's zero value.
+	Defaults map[string]string
+	// Choices maps an argument's name to the choices of it's option on platforms
+	// with structured commands, such as Discord's application commands.
+	Choices map[string][]*Choice
+	// Hidden prevents the command from being shown in the help message.
+	Hidden bool
+	// Deprecated is a deprecation notice for the command, an empty notice
+	// means the command is not deprecated.
+	Deprecated string
+	// Middleware is ran only for this command, after any router and category middleware.
+	Middleware []Middleware
+	// Permissions are the permission bits the user requires to run the command,
+	// they are checked by a CheckTransport using it's platform's permissions,
+	// such as disgord.PermissionBanMembers for the discord package.
+	Permissions uint64
+	// BotPermissions are the permission bits the bot requires to run the command.
+	BotPermissions uint64
+	// Restrictions restrict where and by who the command can be used.
+	Restrictions Restrictions
+	// Cooldown limits how often the command can be used, nil means no cooldown.
+	Cooldown *Cooldown
+	// Concurrency limits the amount of in-flight executions of the command,
+	// every limit must be satisfied for the command to run.
+	Concurrency []Concurrency
+	// Timeout is how long the command's context lasts, overriding the router's default timeout.
+	Timeout time.Duration
+}
+
 var ignoredRegistrarMethods []string
 
 func init() {
@@ -83,8 +144,9 @@ func getRegistrarMethods(i Registrar) ([]reflect.Value, []reflect.Method, error)
 			continue
 		}
 
-		// Check if the first method argument is not *disgord.MessageCreate.
-		if method.Type.In(1) != typeMessageCreate {
+		// Check if the first method argument is not a platform's event, *router.Context or router.Message,
+		// a context.Context is allowed before a platform's event or router.Message.
+		if _, _, offset := getSignature(method.Type, 1); offset < 0 {
 			continue
 		}
 
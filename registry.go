@@ -0,0 +1,112 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Register registers the commands on a registrar, no commands are registered
+// if any of their names or aliases are already registered.
+//
+// Register is safe to call while the router is handling events.
+func (r *Router) Register(registrar Registrar) error {
+	if registrar == nil {
+		return ErrMissingRegistrar
+	}
+
+	commands, err := r.getCommands(registrar)
+	if err != nil {
+		return err
+	}
+
+	if err := r.checkStrict(registrar, commands); err != nil {
+		return err
+	}
+
+	return r.addCommands(commands)
+}
+
+// Unregister removes a command and it's aliases from the router, name may be
+// the command's name or one of it's aliases. Executions of the command that
+// have already started are not affected.
+//
+// Unregister is safe to call while the router is handling events.
+func (r *Router) Unregister(name string) error {
+	r.commandsMx.Lock()
+	defer r.commandsMx.Unlock()
+
+	command, ok := r.commandsByName[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("router: %s is not registered", name)
+	}
+
+	commands := make([]*Command, 0, len(r.Commands))
+	for _, c := range r.Commands {
+		if c != command {
+			commands = append(commands, c)
+		}
+	}
+
+	commandsByName := make(map[string]*Command, len(r.commandsByName))
+	for n, c := range r.commandsByName {
+		if c != command {
+			commandsByName[n] = c
+		}
+	}
+
+	r.Commands = commands
+	r.commandsByName = commandsByName
+	delete(r.disabled, command.name)
+	return nil
+}
+
+// Enable enables a command that was disabled using Disable, name may be the
+// command's name or one of it's aliases.
+//
+// Enable is safe to call while the router is handling events.
+func (r *Router) Enable(name string) error {
+	return r.setEnabled(name, true)
+}
+
+// Disable disables a command without unregistering it, disabled commands are
+// excluded from the help command and HandleMessage returns an
+// *ErrCommandDisabled when they are used. name may be the command's name or one of it's aliases.
+//
+// Disable is safe to call while the router is handling events.
+func (r *Router) Disable(name string) error {
+	return r.setEnabled(name, false)
+}
+
+// IsEnabled returns false if the command with the given name has been disabled using Disable.
+func (r *Router) IsEnabled(name string) bool {
+	r.commandsMx.RLock()
+	defer r.commandsMx.RUnlock()
+
+	_, disabled := r.disabled[name]
+	return !disabled
+}
+
+func (r *Router) setEnabled(name string, enabled bool) error {
+	r.commandsMx.Lock()
+	defer r.commandsMx.Unlock()
+
+	command, ok := r.commandsByName[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("router: %s is not registered", name)
+	}
+
+	if enabled {
+		delete(r.disabled, command.name)
+	} else {
+		r.disabled[command.name] = struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+type pluginRegistrar struct{}
+
+func (c *pluginRegistrar) Plugin(_ *testEvent) error {
+	return nil
+}
+
+func (c *pluginRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *pluginRegistrar) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+func (c *pluginRegistrar) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"plugin": {
+			Aliases: []string{"p"},
+		},
+	}
+}
+
+func TestRouter_Register(t *testing.T) {
+	t.Run("Register", func(t *testing.T) {
+		a := assert.New(t)
+
+		router, err := New(prefix, &commands{})
+		if !a.NoError(err) {
+			return
+		}
+
+		before := router.GetCommands()
+		a.NoError(router.Register(&pluginRegistrar{}))
+		a.NotNil(router.GetCommandByName("plugin"))
+		a.NotNil(router.GetCommandByName("p"))
+		a.Len(router.GetCommands(), len(before)+1)
+		a.NoError(handle(router, newTestEvent(prefix+"p")))
+
+		// Registering the same commands twice must fail without changing the router.
+		a.Error(router.Register(&pluginRegistrar{}))
+		a.Len(router.GetCommands(), len(before)+1)
+	})
+
+	t.Run("Unregister", func(t *testing.T) {
+		a := assert.New(t)
+
+		router, err := New(prefix, &pluginRegistrar{})
+		if !a.NoError(err) {
+			return
+		}
+
+		a.NoError(router.Unregister("p"))
+		a.Nil(router.GetCommandByName("plugin"))
+		a.Nil(router.GetCommandByName("p"))
+		a.Empty(router.GetCommands())
+		a.IsType(&ErrUnknownCommand{}, handle(router, newTestEvent(prefix+"plugin")))
+		a.Error(router.Unregister("plugin"))
+
+		// The command can be registered again once it has been removed.
+		a.NoError(router.Register(&pluginRegistrar{}))
+	})
+
+	t.Run("Disable", func(t *testing.T) {
+		a := assert.New(t)
+
+		router, err := New(prefix, &pluginRegistrar{})
+		if !a.NoError(err) {
+			return
+		}
+
+		a.NoError(router.Disable("plugin"))
+		a.False(router.IsEnabled("plugin"))
+		a.IsType(&ErrCommandDisabled{}, handle(router, newTestEvent(prefix+"p")))
+
+		a.NoError(router.Enable("p"))
+		a.True(router.IsEnabled("plugin"))
+		a.NoError(handle(router, newTestEvent(prefix+"plugin")))
+
+		a.Error(router.Disable("missing"))
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		a := assert.New(t)
+
+		router, err := New(prefix, &commands{})
+		if !a.NoError(err) {
+			return
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_ = router.Register(&pluginRegistrar{})
+				_ = router.Disable("plugin")
+				_ = router.Enable("plugin")
+				_ = router.Unregister("plugin")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_ = handle(router, newTestEvent(prefix+"plugin"))
+			}
+		}()
+		wg.Wait()
+
+		a.Nil(router.GetCommandByName("plugin"))
+	})
+}
@@ -0,0 +1,53 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"reflect"
+)
+
+var typeString = reflect.TypeOf("")
+
+// isValidReturn checks if a method's return values are either an error or a
+// reply followed by an error, replies are strings or the replies of a
+// registered platform, see Platform.
+func isValidReturn(t reflect.Type) bool {
+	switch t.NumOut() {
+	case 1:
+		return t.Out(0).Implements(typeIError)
+	case 2:
+		if !t.Out(1).Implements(typeIError) {
+			return false
+		}
+
+		reply := t.Out(0)
+		return reply == typeString || isPlatformReply(reply)
+	default:
+		return false
+	}
+}
+
+// isEmptyReply returns true if a reply returned by a command has nothing to send,
+// such as an empty string or a nil pointer.
+func isEmptyReply(reply interface{}) bool {
+	switch v := reply.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	}
+
+	// Check for a typed nil to prevent a panic.
+	rv := reflect.ValueOf(reply)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,122 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type replyRegistrar struct{}
+
+func (c *replyRegistrar) Text(_ *testEvent) (string, error) {
+	return "hello", nil
+}
+
+func (c *replyRegistrar) Empty(_ *testEvent) (string, error) {
+	return "", nil
+}
+
+func (c *replyRegistrar) Embed(_ *testEvent) (*testEmbed, error) {
+	return &testEmbed{Title: "hello"}, nil
+}
+
+func (c *replyRegistrar) Nothing(_ *Context) (*testEmbed, error) {
+	return nil, nil
+}
+
+func (c *replyRegistrar) Fail(_ *testEvent) (string, error) {
+	return "ignored", errors.New("failed")
+}
+
+func (c *replyRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *replyRegistrar) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+type invalidReplyRegistrar struct{}
+
+func (c *invalidReplyRegistrar) Number(_ *testEvent) (int, error) {
+	return 0, nil
+}
+
+func (c *invalidReplyRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *invalidReplyRegistrar) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+func TestRouter_HandleMessage_Reply(t *testing.T) {
+	router, err := New(prefix, &replyRegistrar{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		reply   interface{}
+	}{
+		{"String", "text", "hello"},
+		{"EmptyString", "empty", nil},
+		{"Embed", "embed", &testEmbed{Title: "hello"}},
+		{"NilEmbed", "nothing", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			transport := &testTransport{}
+			a.NoError(router.HandleMessage(context.Background(), transport, newTestEvent(prefix+test.content)))
+
+			if test.reply == nil {
+				a.Empty(transport.Replies())
+			} else {
+				a.Equal([]interface{}{test.reply}, transport.Replies())
+			}
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		a := assert.New(t)
+
+		transport := &testTransport{}
+		a.IsType(&ErrCommandExecution{}, router.HandleMessage(context.Background(), transport, newTestEvent(prefix+"fail")))
+		a.Empty(transport.Replies())
+	})
+
+	t.Run("InvalidReturn", func(t *testing.T) {
+		a := assert.New(t)
+
+		_, err := New(prefix, &invalidReplyRegistrar{})
+		a.Equal(ErrMethodHasNoErrorReturn, err)
+	})
+}
+
+func TestGetReplyContent(t *testing.T) {
+	a := assert.New(t)
+
+	content, ok := getReplyContent("hello", nil, time.Second)
+	a.True(ok)
+	a.Equal("hello 1s", content)
+
+	_, ok = getReplyContent(&testEmbed{Title: "hello"})
+	a.False(ok)
+
+	_, ok = getReplyContent()
+	a.False(ok)
+}
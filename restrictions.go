@@ -0,0 +1,111 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+)
+
+// Restrictions represents where and by who a command can be used, IDs are
+// compared to the IDs returned by the message, see Message.
+type Restrictions struct {
+	// OwnerOnly restricts the command to the router's owners.
+	OwnerOnly bool
+	// GuildOnly restricts the command to guilds.
+	GuildOnly bool
+	// DMOnly restricts the command to direct messages.
+	DMOnly bool
+	// NSFWOnly restricts the command to NSFW channels, it's checked by a CheckTransport.
+	NSFWOnly bool
+
+	// Guilds restricts the command to the guilds, ignored if empty.
+	Guilds []string
+	// Channels restricts the command to the channels, ignored if empty.
+	Channels []string
+	// Roles restricts the command to members with any of the roles, ignored if
+	// empty. Roles are checked by a CheckTransport.
+	Roles []string
+}
+
+// WithOwners sets the IDs of the users that are allowed to run owner only commands.
+func WithOwners(ids ...string) Option {
+	return func(r *Router) {
+		r.owners = append(r.owners, ids...)
+	}
+}
+
+// IsOwner returns true if the user is one of the router's owners.
+func (r *Router) IsOwner(id string) bool {
+	return containsString(r.owners, id)
+}
+
+// CanRun returns nil if the command is enabled and the author of the message is allowed
+// to run the command in the message's channel, otherwise the restriction error is returned.
+//
+// Restrictions that depend on the platform, such as permissions, roles and NSFW
+// channels, are checked by the transport if it implements CheckTransport. Commands
+// with such restrictions, or that take a platform's event the message does not
+// have, return an *ErrUnsupportedTransport for any other transport.
+func (r *Router) CanRun(ctx context.Context, t Transport, m Message, command *Command) error {
+	if !r.IsEnabled(command.name) {
+		return &ErrCommandDisabled{Command: command.name}
+	}
+
+	if err := r.checkModule(m, command); err != nil {
+		return err
+	}
+
+	if command.platform != nil {
+		if _, ok := command.platform.GetEvent(ctx, m); !ok {
+			return &ErrUnsupportedTransport{Command: command.name}
+		}
+	}
+
+	restrictions := command.restrictions
+	inGuild := m.GuildID() != ""
+
+	if restrictions.OwnerOnly && !r.IsOwner(m.AuthorID()) {
+		return &ErrOwnerOnly{Command: command.name}
+	}
+
+	if restrictions.GuildOnly && !inGuild {
+		return &ErrGuildOnly{Command: command.name}
+	}
+
+	if restrictions.DMOnly && inGuild {
+		return &ErrDMOnly{Command: command.name}
+	}
+
+	if len(restrictions.Guilds) > 0 && !containsString(restrictions.Guilds, m.GuildID()) {
+		return &ErrRestricted{Command: command.name}
+	}
+
+	if len(restrictions.Channels) > 0 && !containsString(restrictions.Channels, m.ChannelID()) {
+		return &ErrRestricted{Command: command.name}
+	}
+
+	if ct, ok := t.(CheckTransport); ok {
+		return ct.Check(ctx, m, command)
+	}
+
+	if command.permissions != 0 || command.botPermissions != 0 || len(restrictions.Roles) > 0 || restrictions.NSFWOnly {
+		return &ErrUnsupportedTransport{Command: command.name}
+	}
+
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,147 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type restrictionsRegistrar struct{}
+
+func (c *restrictionsRegistrar) Eval(_ *testEvent) error {
+	return nil
+}
+
+func (c *restrictionsRegistrar) Kick(_ *testEvent) error {
+	return nil
+}
+
+func (c *restrictionsRegistrar) Secret(_ *testEvent) error {
+	return nil
+}
+
+func (c *restrictionsRegistrar) Staff(_ *testEvent) error {
+	return nil
+}
+
+func (c *restrictionsRegistrar) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *restrictionsRegistrar) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+func (c *restrictionsRegistrar) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"eval": {
+			Restrictions: Restrictions{OwnerOnly: true},
+		},
+		"kick": {
+			Restrictions: Restrictions{GuildOnly: true},
+		},
+		"secret": {
+			Restrictions: Restrictions{DMOnly: true},
+		},
+		"staff": {
+			Restrictions: Restrictions{
+				GuildOnly: true,
+				Channels:  []string{"10"},
+				Roles:     []string{"20"},
+			},
+		},
+	}
+}
+
+func newRestrictedMessage(content string, author, guild, channel string) *testEvent {
+	e := newTestEvent(content)
+	e.author = author
+	e.guild = guild
+	e.channel = channel
+	return e
+}
+
+func TestRouter_CanRun(t *testing.T) {
+	router, err := New(prefix, &restrictionsRegistrar{}, WithOwners("1"), WithHelp(HelpConfig{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		message *testEvent
+		err     error
+	}{
+		{"Owner", newRestrictedMessage(prefix+"eval", "1", "", "3"), nil},
+		{"NotOwner", newRestrictedMessage(prefix+"eval", "2", "", "3"), &ErrOwnerOnly{Command: "eval"}},
+		{"Guild", newRestrictedMessage(prefix+"kick", "2", "5", "3"), nil},
+		{"NotGuild", newRestrictedMessage(prefix+"kick", "2", "", "3"), &ErrGuildOnly{Command: "kick"}},
+		{"DM", newRestrictedMessage(prefix+"secret", "2", "", "3"), nil},
+		{"NotDM", newRestrictedMessage(prefix+"secret", "2", "5", "3"), &ErrDMOnly{Command: "secret"}},
+		{"Allowed", newRestrictedMessage(prefix+"staff", "2", "5", "10"), nil},
+		{"WrongChannel", newRestrictedMessage(prefix+"staff", "2", "5", "11"), &ErrRestricted{Command: "staff"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			if test.err == nil {
+				a.NoError(handle(router, test.message))
+			} else {
+				a.Equal(test.err, handle(router, test.message))
+			}
+		})
+	}
+
+	t.Run("CheckTransport", func(t *testing.T) {
+		a := assert.New(t)
+
+		// Roles are checked by the transport once the router's restrictions pass.
+		var checked []string
+		transport := &testTransport{check: func(_ Message, command *Command) error {
+			checked = append(checked, command.Name())
+			return &ErrRestricted{Command: command.Name()}
+		}}
+
+		message := newRestrictedMessage(prefix+"staff", "2", "5", "10")
+		a.Equal(&ErrRestricted{Command: "staff"}, router.HandleMessage(context.Background(), transport, message))
+		a.Equal([]string{"staff"}, checked)
+
+		message = newRestrictedMessage(prefix+"staff", "2", "5", "11")
+		a.Equal(&ErrRestricted{Command: "staff"}, router.HandleMessage(context.Background(), transport, message))
+		a.Equal([]string{"staff"}, checked, "transport checked a command the router rejected")
+	})
+
+	t.Run("UnsupportedTransport", func(t *testing.T) {
+		a := assert.New(t)
+
+		// Transports that cannot check roles cannot run commands restricted to roles.
+		transport := TransportFunc(func(_ context.Context, _ Message, _ string) error {
+			return nil
+		})
+
+		message := newRestrictedMessage(prefix+"staff", "2", "5", "10")
+		a.Equal(&ErrUnsupportedTransport{Command: "staff"}, router.HandleMessage(context.Background(), transport, message))
+		a.NoError(router.HandleMessage(context.Background(), transport, newRestrictedMessage(prefix+"kick", "2", "5", "3")))
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		a := assert.New(t)
+
+		message := router.helpList(newHelpContext(router, newRestrictedMessage(prefix+"help", "2", "5", "11")), 1)
+		if a.NotNil(message) && a.Len(message.Fields, 1) {
+			a.NotContains(message.Fields[0].Value, "eval")
+			a.NotContains(message.Fields[0].Value, "secret")
+			a.NotContains(message.Fields[0].Value, "staff")
+			a.Contains(message.Fields[0].Value, "kick")
+		}
+	})
+}
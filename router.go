@@ -9,16 +9,16 @@
 package router // import "go.matthewp.io/router"
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/andersfylling/disgord"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 )
 
 var (
-	// ErrMissingClient .
-	ErrMissingClient = errors.New("router: missing client")
 	// ErrInvalidPrefix .
 	ErrInvalidPrefix = errors.New("router: invalid prefix")
 	// ErrMissingRegistrar .
@@ -28,179 +28,387 @@ var (
 	// ErrCommandIsNil represents a Command Is Nil error.
 	ErrCommandIsNil = errors.New("router: command is nil")
 	// ErrMethodHasNoErrorReturn .
-	ErrMethodHasNoErrorReturn = errors.New("router: method has no error return")
+	ErrMethodHasNoErrorReturn = errors.New("router: method does not return an error or a reply and an error")
 	// ErrMethodHasNoArguments .
 	ErrMethodHasNoArguments = errors.New("router: method has no arguments")
 	// ErrMissingMessageCreateArgument .
-	ErrMissingMessageCreateArgument = errors.New("router: missing *disgord.MessageCreate as the first method argument")
+	ErrMissingMessageCreateArgument = errors.New("router: missing *router.Context, router.Message or a platform's event as the first method argument")
+	// ErrRouterClosed is returned by HandleMessage once the router has been shut down.
+	ErrRouterClosed = errors.New("router: router is shut down")
+	// ErrUnsupportedReply is returned when a command's reply cannot be sent by the message's transport.
+	ErrUnsupportedReply = errors.New("router: reply is not supported by the transport")
 
 	// nilV is used to represent a nil reflect#Value.
 	nilV = reflect.Value{}
 
-	typeMessageCreate    = reflect.TypeOf((*disgord.MessageCreate)(nil))
+	typeIMessage         = reflect.TypeOf((*Message)(nil)).Elem()
+	typeContext          = reflect.TypeOf((*context.Context)(nil)).Elem()
+	typeRouterContext    = reflect.TypeOf((*Context)(nil))
 	typeIError           = reflect.TypeOf((*error)(nil)).Elem()
 	typeIParseable       = reflect.TypeOf((*Parseable)(nil)).Elem()
 	typeIManualParseable = reflect.TypeOf((*ManualParseable)(nil)).Elem()
 	typeIFormatter       = reflect.TypeOf((*Formatter)(nil)).Elem()
+	typeIOptionTyper     = reflect.TypeOf((*OptionTyper)(nil)).Elem()
 )
 
 // Router .
 type Router struct {
-	*disgord.Client
-
 	Prefix    string
 	registrar Registrar
 
+	// Commands is an ordered list of every registered command, used for help listings.
+	// Commands is replaced when commands are registered or unregistered, use
+	// GetCommands if Register or Unregister may be called concurrently.
 	Commands []*Command
+
+	// commandsByName maps a command's name and aliases to the command, used for command lookups.
+	commandsByName map[string]*Command
+
+	// disabled is the set of names of disabled commands.
+	disabled map[string]struct{}
+
+	// disabledModules maps a module's name to the guilds it is disabled in.
+	disabledModules map[string]map[string]struct{}
+
+	// commandsMx protects Commands, commandsByName, disabled and disabledModules.
+	commandsMx sync.RWMutex
+
+	// modules are the registered modules, in the order they were registered.
+	modules        []Module
+	initialModules []Module
+
+	// registering are the names of the modules being initialized, reserving the
+	// names while Init is called without modulesMx held.
+	registering map[string]struct{}
+
+	// modulesMx protects modules and registering, it's never held while a
+	// module's hooks are called so the hooks can use the module APIs.
+	modulesMx sync.Mutex
+
+	help *HelpConfig
+
+	owners      []string
+	buckets     BucketStore
+	rateLimiter *rateLimiter
+	concurrency *concurrencyLimiter
+
+	middleware         []Middleware
+	categoryMiddleware map[string][]Middleware
+
+	// strict makes registration fail if a registrar has any problems, see WithStrict.
+	strict bool
+
+	// timeout is the default timeout for commands, zero means no timeout.
+	timeout time.Duration
+
+	// pool runs commands asynchronously, nil if commands are ran by HandleMessage.
+	pool       *workerPool
+	poolConfig *WorkerPoolConfig
+
+	// mx protects closed, aborted, inflight, nextID and drained.
+	mx       sync.Mutex
+	closed   bool
+	aborted  bool
+	inflight map[uint64]context.CancelFunc
+	nextID   uint64
+
+	// drained is closed once every command has finished after Shutdown is
+	// called, it's created by the first call to Shutdown.
+	drained chan struct{}
+
+	// teardown tears down the modules once, teardownErr is it's result.
+	teardown    sync.Once
+	teardownErr error
+
+	// wg tracks calls to HandleMessage and queued commands.
+	wg sync.WaitGroup
 }
 
-// NewRouter .
-func NewRouter(client *disgord.Client, prefix string, i Registrar) (*Router, error) {
-	if client == nil {
-		return nil, ErrMissingClient
-	}
+// Option represents an option that can be passed to New.
+type Option func(*Router)
 
+// New returns a new router, messages are passed to HandleMessage by a Transport.
+// The discord package's NewRouter returns a router that handles Discord events.
+func New(prefix string, i Registrar, opts ...Option) (*Router, error) {
 	if len(prefix) != 1 {
 		return nil, ErrInvalidPrefix
 	}
 
-	if i == nil {
-		return nil, ErrMissingRegistrar
-	}
-
 	r := &Router{
-		Client: client,
-
 		Prefix:    prefix,
 		registrar: i,
+
+		concurrency: newConcurrencyLimiter(),
+
+		disabled:        make(map[string]struct{}),
+		disabledModules: make(map[string]map[string]struct{}),
+		registering:     make(map[string]struct{}),
+		inflight:        make(map[uint64]context.CancelFunc),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if i == nil && len(r.initialModules) < 1 {
+		return nil, ErrMissingRegistrar
+	}
+
+	if r.buckets == nil {
+		r.buckets = NewMemoryBucketStore()
 	}
 
 	if err := r.registerCommands(); err != nil {
 		return nil, err
 	}
+
+	for _, module := range r.initialModules {
+		if err := r.RegisterModule(module); err != nil {
+			_ = r.teardownModules()
+			return nil, err
+		}
+	}
+
+	if r.poolConfig != nil {
+		r.pool = newWorkerPool(r, *r.poolConfig)
+	}
 	return r, nil
 }
 
-// GetCommandByName attempts to get a *Command by matching it's name.
+// GetCommandByName attempts to get a *Command by matching it's name or one of it's aliases.
 func (r *Router) GetCommandByName(name string) *Command {
-	for _, c := range r.Commands {
-		if c.name == name {
-			return c
-		}
-	}
+	r.commandsMx.RLock()
+	defer r.commandsMx.RUnlock()
+	return r.commandsByName[name]
+}
 
-	return nil
+// GetCommands returns an ordered list of every registered command.
+func (r *Router) GetCommands() []*Command {
+	r.commandsMx.RLock()
+	defer r.commandsMx.RUnlock()
+	return r.Commands
 }
 
 // registerCommands registers the commands on the registrar.
 func (r *Router) registerCommands() error {
-	values, methods, err := getRegistrarMethods(r.registrar)
-	if err != nil {
+	var commands []*Command
+	if r.registrar != nil {
+		var err error
+		commands, err = r.getCommands(r.registrar)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Register the built-in help command unless the registrar provides it's own.
+	if r.help != nil {
+		command := r.getHelpCommand()
+
+		registered := false
+		for _, c := range commands {
+			if c.name == command.name {
+				registered = true
+				break
+			}
+		}
+
+		if !registered {
+			commands = append(commands, command)
+		}
+	}
+
+	if err := r.checkStrict(r.registrar, commands); err != nil {
 		return err
 	}
 
-	commands := make([]*Command, 0)
+	return r.addCommands(commands)
+}
+
+// getCommands returns the commands on a registrar.
+func (r *Router) getCommands(registrar Registrar) ([]*Command, error) {
+	// Use the generated dispatch code instead of reflection if the registrar has any.
+	if g, ok := registrar.(GeneratedRegistrar); ok {
+		return r.getGeneratedCommands(g)
+	}
+
+	values, methods, err := getRegistrarMethods(registrar)
+	if err != nil {
+		return nil, err
+	}
+
+	commands := make([]*Command, 0, len(values))
 	for i := 0; i < len(values); i++ {
-		command, err := r.getCommand(values[i], methods[i])
+		command, err := r.getCommand(registrar, values[i], methods[i])
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if command == nil {
-			return ErrCommandIsNil
+			return nil, ErrCommandIsNil
 		}
 
 		commands = append(commands, command)
 	}
 
-	r.Commands = commands
+	return commands, nil
+}
+
+// addCommands adds commands to the router, no commands are added if any of
+// their names or aliases are already registered.
+func (r *Router) addCommands(commands []*Command) error {
+	r.commandsMx.Lock()
+	defer r.commandsMx.Unlock()
+
+	commandsByName, err := r.indexCommands(commands)
+	if err != nil {
+		return err
+	}
+
+	// The list is copied so slices returned by GetCommands are never modified.
+	all := make([]*Command, 0, len(r.Commands)+len(commands))
+	all = append(all, r.Commands...)
+	all = append(all, commands...)
+
+	r.Commands = all
+	r.commandsByName = commandsByName
 	return nil
 }
 
-func (r *Router) getCommand(value reflect.Value, method reflect.Method) (*Command, error) {
-	// Check if the method does not return anything to prevent a panic.
-	if value.Type().NumOut() < 1 {
-		return nil, ErrMethodHasNoErrorReturn
+// indexCommands returns a copy of commandsByName with the names and aliases of
+// the commands added, an error is returned if any of them are already registered.
+// r.commandsMx must be held by the caller.
+func (r *Router) indexCommands(commands []*Command) (map[string]*Command, error) {
+	commandsByName := make(map[string]*Command, len(r.commandsByName)+len(commands))
+	for name, command := range r.commandsByName {
+		commandsByName[name] = command
+	}
+
+	for _, command := range commands {
+		if existing, ok := commandsByName[command.name]; ok {
+			return nil, errAlreadyRegistered(command.name, existing)
+		}
+		commandsByName[command.name] = command
+	}
+
+	// Aliases are indexed after every name so a name always takes precedence.
+	for _, command := range commands {
+		for _, alias := range command.aliases {
+			if existing, ok := commandsByName[alias]; ok {
+				return nil, errAlreadyRegistered(command.name+"'s alias "+alias, existing)
+			}
+			commandsByName[alias] = command
+		}
+	}
+
+	return commandsByName, nil
+}
+
+// errAlreadyRegistered returns the error for a name that is already used by an existing command.
+func errAlreadyRegistered(name string, existing *Command) error {
+	return fmt.Errorf("router: %s", alreadyRegistered(name, existing))
+}
+
+// alreadyRegistered describes a name that is already used by an existing command.
+func alreadyRegistered(name string, existing *Command) string {
+	if existing.module != "" {
+		return name + " is already registered by module " + existing.module
 	}
 
-	// Check if the first return value is not an error
-	if err := value.Type().Out(0); err == nil || !err.Implements(typeIError) {
+	return name + " is already registered"
+}
+
+func (r *Router) getCommand(registrar Registrar, value reflect.Value, method reflect.Method) (*Command, error) {
+	// Check if the method returns an error, optionally preceded by a reply.
+	if !isValidReturn(value.Type()) {
 		return nil, ErrMethodHasNoErrorReturn
 	}
 
 	// The first argument will always be the struct value, so we ignore it
-	args := method.Type.NumIn()
-	if args < 2 {
+	if method.Type.NumIn() < 2 {
 		return nil, ErrMethodHasNoArguments
 	}
 
-	// Check first argument type
-	if method.Type.In(1) != typeMessageCreate {
+	// offset is the index of the first command argument.
+	sig, platform, offset := getSignature(method.Type, 1)
+	if offset < 0 {
 		return nil, ErrMissingMessageCreateArgument
 	}
 
 	// Create a new command
-	command := &Command{
-		name: strings.ToLower(method.Name),
-
-		value:  value,
-		method: method,
+	command := newCommand(strings.ToLower(method.Name), value, sig)
+	command.platform = platform
+	command.method = method
+	command.Description = registrar.Descriptions()[command.name]
 
-		arguments: make([]argumentValueFn, 0, args),
-
-		rawArgumentsIndex: -1,
+	metadata := getMetadata(registrar, command.name)
+	if err := command.setMetadata(metadata); err != nil {
+		return nil, err
 	}
-	command.Description = r.registrar.Descriptions()[command.name]
 
 	// Handle method arguments
-	if args > 2 {
-		methodArgs, ok := r.registrar.Arguments()[command.name]
+	if method.Type.NumIn() > offset {
+		methodArgs, ok := registrar.Arguments()[command.name]
 		if !ok {
 			return nil, fmt.Errorf("router: %s takes arguments and does not have a usage", method.Name)
 		}
 
-		if args-2 != len(methodArgs) {
-			return nil, fmt.Errorf("router: %s's usage does not have all the arguments present", method.Name)
+		if err := command.setArguments(method.Name, method.Type, offset, methodArgs, metadata.ArgumentDescriptions); err != nil {
+			return nil, err
 		}
+	}
 
-		var usageBuilder strings.Builder
-
-		for i := 2; i < args; i++ {
-			t := method.Type.In(i)
+	if err := command.setOptional(method.Name, metadata.Optional); err != nil {
+		return nil, err
+	}
 
-			if t.Implements(typeIManualParseable) {
-				command.rawArgumentsIndex = i - 2
-			}
+	if err := command.setDefaults(method.Name, metadata.Defaults); err != nil {
+		return nil, err
+	}
 
-			argValue, err := getArgumentValueFn(t)
-			if err != nil {
-				return nil, fmt.Errorf("router: error parsing argument %s: %v", t.String(), err)
-			}
+	return command, nil
+}
 
-			var usage string
-			if t.Implements(typeIFormatter) {
-				mt, ok := t.MethodByName("Format")
-				if !ok {
-					panic("router: type IFormatter does not implement Format")
-				}
+// getMetadata returns the metadata for a command if the registrar implements MetadataRegistrar.
+func getMetadata(registrar Registrar, name string) Metadata {
+	m, ok := registrar.(MetadataRegistrar)
+	if !ok {
+		return Metadata{}
+	}
 
-				v := reflect.New(t.Elem())
+	return m.Metadata()[name]
+}
 
-				ret := mt.Func.Call([]reflect.Value{
-					v, reflect.ValueOf(methodArgs[i-2]),
-				})
+// getSignature returns the signature of a function, the platform of the event
+// it takes and the index of it's first command argument, the index is -1 if the
+// function is not a valid command. first is the index of the function's first
+// parameter, 1 for methods to skip the receiver.
+func getSignature(t reflect.Type, first int) (signature, *Platform, int) {
+	if t.NumIn() <= first {
+		return 0, nil, -1
+	}
 
-				usage = ret[0].Interface().(string)
-			} else {
-				usage = "<" + methodArgs[i-2] + ": " + t.String() + ">"
-			}
+	switch t.In(first) {
+	case typeRouterContext:
+		return signatureContext, nil, first + 1
+	case typeIMessage:
+		return signatureMessage, nil, first + 1
+	case typeContext:
+		if t.NumIn() <= first+1 {
+			break
+		}
 
-			command.arguments = append(command.arguments, argValue)
-			usageBuilder.WriteString(" " + usage)
+		if t.In(first+1) == typeIMessage {
+			return signatureContextMessage, nil, first + 2
 		}
 
-		command.usage = usageBuilder.String()
+		if platform := getEventPlatform(t.In(first + 1)); platform != nil {
+			return signatureContextEvent, platform, first + 2
+		}
+	default:
+		if platform := getEventPlatform(t.In(first)); platform != nil {
+			return signatureEvent, platform, first + 1
+		}
 	}
 
-	return command, nil
+	return 0, nil, -1
 }
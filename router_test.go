@@ -8,7 +8,6 @@
 package router
 
 import (
-	"github.com/andersfylling/disgord"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -20,7 +19,7 @@ var (
 
 type commands struct{}
 
-func (c *commands) Yay(_ *disgord.MessageCreate) error {
+func (c *commands) Yay(_ *testEvent) error {
 	return nil
 }
 
@@ -33,7 +32,7 @@ func (c *commands) Arguments() map[string][]string {
 }
 
 func newRouter() (*Router, error) {
-	return NewRouter(&disgord.Client{}, prefix, cmds)
+	return New(prefix, cmds)
 }
 
 func TestNewRouter(t *testing.T) {
@@ -45,28 +44,16 @@ func TestNewRouter(t *testing.T) {
 		a.NoError(err)
 		a.NotNil(router)
 
-		a.NotNil(router.Client)
-
 		a.Equal(prefix, router.Prefix)
 		a.NotNil(router.registrar)
 
 		a.NotNil(router.Commands, 0)
 	})
 
-	t.Run("MissingClient", func(t *testing.T) {
-		a := assert.New(t)
-
-		router, err := NewRouter(nil, prefix, cmds)
-		if a.Error(err) {
-			a.Equal(ErrMissingClient, err)
-		}
-		a.Nil(router)
-	})
-
 	t.Run("InvalidPrefix", func(t *testing.T) {
 		a := assert.New(t)
 
-		router, err := NewRouter(&disgord.Client{}, "", cmds)
+		router, err := New("", cmds)
 		if a.Error(err) {
 			a.Equal(ErrInvalidPrefix, err)
 		}
@@ -76,7 +63,7 @@ func TestNewRouter(t *testing.T) {
 	t.Run("MissingRegistrar", func(t *testing.T) {
 		a := assert.New(t)
 
-		router, err := NewRouter(&disgord.Client{}, prefix, nil)
+		router, err := New(prefix, nil)
 		if a.Error(err) {
 			a.Equal(ErrMissingRegistrar, err)
 		}
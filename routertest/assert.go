@@ -0,0 +1,100 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package routertest
+
+import (
+	"reflect"
+	"testing"
+)
+
+// AssertSent asserts that a message with the given content was sent, returning true if it was.
+func (s *Session) AssertSent(tb testing.TB, content string) bool {
+	tb.Helper()
+
+	messages := s.Messages()
+	for _, message := range messages {
+		if message.Content == content {
+			return true
+		}
+	}
+
+	tb.Errorf("routertest: expected a message with content %q, sent messages: %s", content, formatMessages(messages))
+	return false
+}
+
+// AssertSentEmbed asserts that an embed with the given title was sent, returning true if it was.
+func (s *Session) AssertSentEmbed(tb testing.TB, title string) bool {
+	tb.Helper()
+
+	messages := s.Messages()
+	for _, message := range messages {
+		if message.Embed != nil && message.Embed.Title == title {
+			return true
+		}
+	}
+
+	tb.Errorf("routertest: expected an embed with title %q, sent messages: %s", title, formatMessages(messages))
+	return false
+}
+
+// AssertNothingSent asserts that no messages were sent, returning true if none were.
+func (s *Session) AssertNothingSent(tb testing.TB) bool {
+	tb.Helper()
+
+	if messages := s.Messages(); len(messages) > 0 {
+		tb.Errorf("routertest: expected no messages, sent messages: %s", formatMessages(messages))
+		return false
+	}
+
+	return true
+}
+
+// AssertReacted asserts that the emoji was added as a reaction, returning true if it was.
+func (s *Session) AssertReacted(tb testing.TB, emoji interface{}) bool {
+	tb.Helper()
+
+	for _, reaction := range s.Reactions() {
+		if reflect.DeepEqual(reaction.Emoji, emoji) {
+			return true
+		}
+	}
+
+	tb.Errorf("routertest: expected a reaction with %v", emoji)
+	return false
+}
+
+// AssertError asserts that err has the same type as target, for example
+// AssertError(t, err, &router.ErrUnknownCommand{}), returning true if it does.
+func AssertError(tb testing.TB, err error, target error) bool {
+	tb.Helper()
+
+	if reflect.TypeOf(err) != reflect.TypeOf(target) {
+		tb.Errorf("routertest: expected an error of type %T, got %T: %v", target, err, err)
+		return false
+	}
+
+	return true
+}
+
+// formatMessages formats messages for an assertion's failure message.
+func formatMessages(messages []*Message) string {
+	if len(messages) < 1 {
+		return "none"
+	}
+
+	s := ""
+	for _, message := range messages {
+		s += "\n\t"
+		if message.Embed != nil {
+			s += "[embed " + message.Embed.Title + "] "
+		}
+		s += message.Content
+	}
+
+	return s
+}
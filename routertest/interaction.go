@@ -0,0 +1,84 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package routertest
+
+import (
+	"context"
+	"encoding/json"
+	"go.matthewp.io/router/discord"
+	"io/ioutil"
+	"testing"
+)
+
+// Response represents a response to an interaction sent using the session.
+type Response struct {
+	Interaction *discord.Interaction
+	*discord.InteractionResponse
+}
+
+var _ discord.InteractionResponder = (*Session)(nil)
+
+// ReadInteraction reads an interaction from a JSON fixture, such as the payload
+// of an INTERACTION_CREATE event, the test fails immediately if it cannot be read.
+func ReadInteraction(tb testing.TB, path string) *discord.Interaction {
+	tb.Helper()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		tb.Fatalf("routertest: failed to read interaction: %v", err)
+	}
+
+	i := &discord.Interaction{}
+	if err := json.Unmarshal(data, i); err != nil {
+		tb.Fatalf("routertest: failed to parse interaction %s: %v", path, err)
+	}
+
+	return i
+}
+
+// Respond records a response to an interaction.
+func (s *Session) Respond(_ context.Context, i *discord.Interaction, response *discord.InteractionResponse) error {
+	if s.Err != nil {
+		return s.Err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.responses = append(s.responses, &Response{
+		Interaction:         i,
+		InteractionResponse: response,
+	})
+	return nil
+}
+
+// Responses returns every interaction response sent using the session, in the order they were sent.
+func (s *Session) Responses() []*Response {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	responses := make([]*Response, len(s.responses))
+	copy(responses, s.responses)
+	return responses
+}
+
+// AssertResponded asserts that an interaction was responded to with the given
+// content, returning true if it was.
+func (s *Session) AssertResponded(tb testing.TB, content string) bool {
+	tb.Helper()
+
+	responses := s.Responses()
+	for _, response := range responses {
+		if response.Data != nil && response.Data.Content == content {
+			return true
+		}
+	}
+
+	tb.Errorf("routertest: expected an interaction response with content %q, sent %d responses", content, len(responses))
+	return false
+}
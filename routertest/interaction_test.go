@@ -0,0 +1,47 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package routertest
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router/discord"
+	"testing"
+)
+
+func TestSession_Respond(t *testing.T) {
+	a := assert.New(t)
+
+	r, session := NewRouter(t, ".", &replCommands{})
+
+	i := ReadInteraction(t, "../discord/testdata/interactions/ping.json")
+	a.NoError(r.HandleInteraction(context.Background(), session, i))
+
+	i = &discord.Interaction{
+		Type:      discord.InteractionApplicationCommand,
+		ChannelID: DefaultChannelID,
+		User:      DefaultAuthor,
+		Data:      &discord.InteractionData{Name: "ping"},
+	}
+	a.NoError(r.HandleInteraction(context.Background(), session, i))
+
+	responses := session.Responses()
+	if a.Len(responses, 2) {
+		a.Equal(discord.InteractionResponsePong, responses[0].Type)
+		a.Equal(i, responses[1].Interaction)
+	}
+
+	rec := &recorder{TB: t}
+	a.True(session.AssertResponded(rec, "Pong!"))
+	a.False(rec.failed)
+	a.False(session.AssertResponded(rec, "Ping!"))
+	a.True(rec.failed)
+
+	session.Reset()
+	a.Empty(session.Responses())
+}
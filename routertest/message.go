@@ -0,0 +1,117 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package routertest
+
+import (
+	"context"
+	"github.com/andersfylling/disgord"
+	"sync/atomic"
+)
+
+// DefaultChannelID is the channel messages are sent in unless another channel is set.
+const DefaultChannelID disgord.Snowflake = 10
+
+// DefaultAuthor is the author of messages unless another author is set.
+var DefaultAuthor = &disgord.User{
+	ID:       100,
+	Username: "user",
+}
+
+// messageID is the ID of the last message created by a MessageBuilder.
+var messageID uint64 = 10000
+
+// NewMessageCreate returns a *disgord.MessageCreate for a message sent by author
+// in the default channel, the default author is used if author is nil.
+func NewMessageCreate(content string, author *disgord.User) *disgord.MessageCreate {
+	b := NewMessage(content)
+	if author != nil {
+		b.Author(author)
+	}
+
+	return b.Build()
+}
+
+// MessageBuilder builds a *disgord.MessageCreate.
+type MessageBuilder struct {
+	ctx context.Context
+
+	content   string
+	author    *disgord.User
+	channelID disgord.Snowflake
+	guildID   disgord.Snowflake
+	roles     []disgord.Snowflake
+}
+
+// NewMessage returns a new MessageBuilder for a message sent by the default
+// author in the default channel, outside of a guild.
+func NewMessage(content string) *MessageBuilder {
+	return &MessageBuilder{
+		content:   content,
+		author:    DefaultAuthor,
+		channelID: DefaultChannelID,
+	}
+}
+
+// Author sets the message's author.
+func (b *MessageBuilder) Author(author *disgord.User) *MessageBuilder {
+	b.author = author
+	return b
+}
+
+// Channel sets the channel the message is sent in.
+func (b *MessageBuilder) Channel(id disgord.Snowflake) *MessageBuilder {
+	b.channelID = id
+	return b
+}
+
+// Guild sets the guild the message is sent in.
+func (b *MessageBuilder) Guild(id disgord.Snowflake) *MessageBuilder {
+	b.guildID = id
+	return b
+}
+
+// Roles sets the roles of the message's author, the message must be sent in a guild.
+func (b *MessageBuilder) Roles(roles ...disgord.Snowflake) *MessageBuilder {
+	b.roles = roles
+	return b
+}
+
+// Context sets the event's context.
+func (b *MessageBuilder) Context(ctx context.Context) *MessageBuilder {
+	b.ctx = ctx
+	return b
+}
+
+// Build returns a new *disgord.MessageCreate, every message built is given a unique ID.
+func (b *MessageBuilder) Build() *disgord.MessageCreate {
+	ctx := b.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	message := &disgord.Message{
+		ID:        disgord.Snowflake(atomic.AddUint64(&messageID, 1)),
+		ChannelID: b.channelID,
+		GuildID:   b.guildID,
+		Content:   b.content,
+		Author:    b.author,
+	}
+
+	// Discord includes a partial member on messages sent in guilds.
+	if !b.guildID.IsZero() {
+		message.Member = &disgord.Member{
+			GuildID: b.guildID,
+			Roles:   b.roles,
+		}
+	}
+
+	return &disgord.MessageCreate{
+		Message: message,
+		Ctx:     ctx,
+	}
+}
@@ -0,0 +1,199 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package routertest
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/andersfylling/disgord"
+	"go.matthewp.io/router/discord"
+	"io"
+	"strings"
+)
+
+// DefaultPrompt is the prompt written before every line unless another prompt is set.
+const DefaultPrompt = "> "
+
+// REPLConfig represents the configuration of a REPL.
+type REPLConfig struct {
+	// Author is the user that sends every message, defaults to DefaultAuthor.
+	Author *disgord.User
+	// ChannelID is the channel messages are sent in, defaults to DefaultChannelID.
+	ChannelID disgord.Snowflake
+	// GuildID is the guild messages are sent in, zero sends direct messages.
+	GuildID disgord.Snowflake
+	// Roles are the roles of the author in the guild.
+	Roles []disgord.Snowflake
+	// Permissions are the permissions of the guild's @everyone role, which are
+	// used by both the author and the bot, defaults to administrator.
+	Permissions disgord.PermissionBits
+	// Prompt is written before every line, defaults to DefaultPrompt.
+	Prompt string
+}
+
+// REPL reads lines from in and handles each line as a message until in is
+// exhausted, the replies, reactions and errors of every command are written to out.
+//
+// The router must be using the session and must not have a worker pool, the
+// prefix is added to lines that do not start with it. If the config has a guild
+// that has not been added to the session, the guild, channel and both members
+// are added to the session.
+func REPL(r *discord.Router, session *Session, in io.Reader, out io.Writer, config REPLConfig) error {
+	if config.Author == nil {
+		config.Author = DefaultAuthor
+	}
+
+	if config.ChannelID.IsZero() {
+		config.ChannelID = DefaultChannelID
+	}
+
+	if config.Prompt == "" {
+		config.Prompt = DefaultPrompt
+	}
+
+	if !config.GuildID.IsZero() {
+		session.addREPLGuild(config)
+	}
+
+	w := bufio.NewWriter(out)
+	scanner := bufio.NewScanner(in)
+	for {
+		if _, err := w.WriteString(config.Prompt); err != nil {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if !scanner.Scan() {
+			break
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, r.Prefix) {
+			line = r.Prefix + line
+		}
+
+		messages, reactions := len(session.Messages()), len(session.Reactions())
+
+		e := NewMessage(line).
+			Author(config.Author).
+			Channel(config.ChannelID).
+			Guild(config.GuildID).
+			Roles(config.Roles...).
+			Build()
+		err := r.Handle(e)
+
+		for _, message := range session.Messages()[messages:] {
+			writeMessage(w, message, config.ChannelID)
+		}
+
+		for _, reaction := range session.Reactions()[reactions:] {
+			_, _ = fmt.Fprintf(w, "reacted with %v\n", reaction.Emoji)
+		}
+
+		if err != nil {
+			_, _ = fmt.Fprintf(w, "error: %v\n", err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	// End the prompt's line once in is exhausted.
+	if _, err := w.WriteString("\n"); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// addREPLGuild adds the guild, channel and members used by a REPL to the session,
+// nothing is added if the guild has already been added.
+func (s *Session) addREPLGuild(config REPLConfig) {
+	s.mx.Lock()
+	_, ok := s.guilds[config.GuildID]
+	s.mx.Unlock()
+	if ok {
+		return
+	}
+
+	permissions := config.Permissions
+	if permissions == 0 {
+		permissions = disgord.PermissionAdministrator
+	}
+
+	s.AddGuild(&disgord.Guild{
+		ID:   config.GuildID,
+		Name: "repl",
+	}, &disgord.Role{
+		ID:          config.GuildID,
+		Name:        "@everyone",
+		Permissions: permissions,
+	})
+
+	s.AddChannel(&disgord.Channel{
+		ID:      config.ChannelID,
+		GuildID: config.GuildID,
+		Type:    disgord.ChannelTypeGuildText,
+		Name:    "repl",
+	})
+
+	s.AddMember(config.GuildID, &disgord.Member{
+		GuildID: config.GuildID,
+		User:    config.Author,
+		Roles:   config.Roles,
+	})
+	s.AddMember(config.GuildID, &disgord.Member{
+		GuildID: config.GuildID,
+		User:    s.user,
+	})
+}
+
+// writeMessage writes a message sent by a command, messages sent to other
+// channels are labelled with the channel's ID.
+func writeMessage(w io.Writer, message *Message, channelID disgord.Snowflake) {
+	if message.ChannelID != channelID {
+		_, _ = fmt.Fprintf(w, "[%s] ", message.ChannelID)
+	}
+
+	if message.Content != "" {
+		_, _ = fmt.Fprintln(w, message.Content)
+	}
+
+	embed := message.Embed
+	if embed == nil {
+		return
+	}
+
+	writeEmbedLines(w, embed.Title)
+	writeEmbedLines(w, embed.Description)
+
+	for _, field := range embed.Fields {
+		writeEmbedLines(w, field.Name+": "+field.Value)
+	}
+
+	if embed.Footer != nil {
+		writeEmbedLines(w, embed.Footer.Text)
+	}
+}
+
+// writeEmbedLines writes every line of text with a leading bar to show it is part of an embed.
+func writeEmbedLines(w io.Writer, text string) {
+	if text == "" {
+		return
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		_, _ = fmt.Fprintf(w, "| %s\n", line)
+	}
+}
@@ -0,0 +1,119 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package routertest
+
+import (
+	"bytes"
+	"github.com/andersfylling/disgord"
+	"github.com/stretchr/testify/assert"
+	"go.matthewp.io/router"
+	"go.matthewp.io/router/discord"
+	"strings"
+	"testing"
+)
+
+type replCommands struct{}
+
+func (c *replCommands) Ping(_ *disgord.MessageCreate) (string, error) {
+	return "Pong!", nil
+}
+
+func (c *replCommands) Info(_ *router.Context) (*disgord.Embed, error) {
+	return &disgord.Embed{
+		Title:       "Info",
+		Description: "line one\nline two",
+		Fields: []*disgord.EmbedField{
+			{Name: "Field", Value: "Value"},
+		},
+	}, nil
+}
+
+func (c *replCommands) Thumbs(ctx *router.Context) error {
+	return discord.React(ctx, "👍")
+}
+
+func (c *replCommands) Whisper(ctx *router.Context) error {
+	_, err := discord.DM(ctx, "psst")
+	return err
+}
+
+func (c *replCommands) Purge(_ *disgord.MessageCreate) (string, error) {
+	return "Purged!", nil
+}
+
+func (c *replCommands) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *replCommands) Arguments() map[string][]string {
+	return map[string][]string{}
+}
+
+func (c *replCommands) Metadata() map[string]router.Metadata {
+	return map[string]router.Metadata{
+		"purge": {
+			Permissions:    disgord.PermissionManageMessages,
+			BotPermissions: disgord.PermissionManageMessages,
+		},
+	}
+}
+
+func TestREPL(t *testing.T) {
+	t.Run("DM", func(t *testing.T) {
+		a := assert.New(t)
+
+		r, session := NewRouter(t, ".", &replCommands{})
+
+		in := strings.NewReader("ping\n\n.info\nthumbs\nwhisper\npurge\nmissing\n")
+		out := &bytes.Buffer{}
+		if !a.NoError(REPL(r, session, in, out, REPLConfig{})) {
+			return
+		}
+
+		a.Equal(strings.Join([]string{
+			"> Pong!",
+			"> > | Info",
+			"| line one",
+			"| line two",
+			"| Field: Value",
+			"> reacted with 👍",
+			"> [100] psst",
+			"> error: You are missing the following permissions: `Manage Messages`",
+			"> error: Unknown Command: `missing`",
+			"> \n",
+		}, "\n"), out.String())
+	})
+
+	t.Run("Guild", func(t *testing.T) {
+		a := assert.New(t)
+
+		r, session := NewRouter(t, ".", &replCommands{})
+
+		in := strings.NewReader("purge\n")
+		out := &bytes.Buffer{}
+		if !a.NoError(REPL(r, session, in, out, REPLConfig{GuildID: 1, Prompt: "$ "})) {
+			return
+		}
+
+		a.Equal("$ Purged!\n$ \n", out.String())
+	})
+
+	t.Run("Permissions", func(t *testing.T) {
+		a := assert.New(t)
+
+		r, session := NewRouter(t, ".", &replCommands{})
+
+		in := strings.NewReader("purge\n")
+		out := &bytes.Buffer{}
+		if !a.NoError(REPL(r, session, in, out, REPLConfig{GuildID: 1, Permissions: disgord.PermissionSendMessages})) {
+			return
+		}
+
+		a.Equal("> error: You are missing the following permissions: `Manage Messages`\n> \n", out.String())
+	})
+}
@@ -0,0 +1,347 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+// Package routertest provides a fake session and helpers for testing commands
+// without connecting to Discord.
+package routertest // import "go.matthewp.io/router/routertest"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/andersfylling/disgord"
+	"go.matthewp.io/router"
+	"go.matthewp.io/router/discord"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ErrNotFound is returned by the session when the requested state has not been added.
+var ErrNotFound = errors.New("routertest: not found")
+
+// BotID is the ID of the user returned by Session#GetCurrentUser.
+const BotID disgord.Snowflake = 1
+
+// Message represents a message sent using the session.
+type Message struct {
+	ChannelID disgord.Snowflake
+	Content   string
+	Embed     *disgord.Embed
+	Params    *disgord.CreateMessageParams
+}
+
+// Reaction represents a reaction added using the session.
+type Reaction struct {
+	ChannelID disgord.Snowflake
+	MessageID disgord.Snowflake
+	Emoji     interface{}
+}
+
+// Session represents a fake discord.Session that records every message, reaction
+// and typing indicator instead of sending them to Discord.
+//
+// Guilds, channels and members used by permission and restriction checks must be
+// added to the session before they are used, ErrNotFound is returned otherwise.
+type Session struct {
+	// Err is returned by every method that sends something, if set.
+	Err error
+
+	mx        sync.Mutex
+	nextID    disgord.Snowflake
+	messages  []*Message
+	reactions []*Reaction
+	typing    []disgord.Snowflake
+	responses []*Response
+
+	user     *disgord.User
+	guilds   map[disgord.Snowflake]*disgord.Guild
+	roles    map[disgord.Snowflake][]*disgord.Role
+	channels map[disgord.Snowflake]*disgord.Channel
+	members  map[disgord.Snowflake]map[disgord.Snowflake]*disgord.Member
+}
+
+var _ discord.Session = (*Session)(nil)
+
+// NewSession returns a new fake session.
+func NewSession() *Session {
+	return &Session{
+		nextID: 1000,
+
+		user: &disgord.User{
+			ID:       BotID,
+			Username: "bot",
+			Bot:      true,
+		},
+		guilds:   make(map[disgord.Snowflake]*disgord.Guild),
+		roles:    make(map[disgord.Snowflake][]*disgord.Role),
+		channels: make(map[disgord.Snowflake]*disgord.Channel),
+		members:  make(map[disgord.Snowflake]map[disgord.Snowflake]*disgord.Member),
+	}
+}
+
+// NewRouter returns a router using a new fake session, the test fails immediately
+// if the router cannot be created.
+func NewRouter(tb testing.TB, prefix string, registrar router.Registrar, opts ...router.Option) (*discord.Router, *Session) {
+	tb.Helper()
+
+	session := NewSession()
+	r, err := discord.NewRouter(session, prefix, registrar, opts...)
+	if err != nil {
+		tb.Fatalf("routertest: failed to create router: %v", err)
+	}
+
+	return r, session
+}
+
+// AddGuild adds a guild and it's roles to the session.
+func (s *Session) AddGuild(guild *disgord.Guild, roles ...*disgord.Role) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.guilds[guild.ID] = guild
+	s.roles[guild.ID] = roles
+}
+
+// AddChannel adds a channel to the session.
+func (s *Session) AddChannel(channel *disgord.Channel) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.channels[channel.ID] = channel
+}
+
+// AddMember adds a member of a guild to the session, the member's user must be set.
+func (s *Session) AddMember(guildID disgord.Snowflake, member *disgord.Member) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	members, ok := s.members[guildID]
+	if !ok {
+		members = make(map[disgord.Snowflake]*disgord.Member)
+		s.members[guildID] = members
+	}
+	members[member.User.ID] = member
+}
+
+// Messages returns every message sent using the session, in the order they were sent.
+func (s *Session) Messages() []*Message {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	messages := make([]*Message, len(s.messages))
+	copy(messages, s.messages)
+	return messages
+}
+
+// LastMessage returns the last message sent using the session, nil if no messages have been sent.
+func (s *Session) LastMessage() *Message {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	if len(s.messages) < 1 {
+		return nil
+	}
+
+	return s.messages[len(s.messages)-1]
+}
+
+// Reactions returns every reaction added using the session, in the order they were added.
+func (s *Session) Reactions() []*Reaction {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	reactions := make([]*Reaction, len(s.reactions))
+	copy(reactions, s.reactions)
+	return reactions
+}
+
+// Typing returns the IDs of the channels a typing indicator was triggered in.
+func (s *Session) Typing() []disgord.Snowflake {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	typing := make([]disgord.Snowflake, len(s.typing))
+	copy(typing, s.typing)
+	return typing
+}
+
+// Reset clears every recorded message, reaction, typing indicator and interaction response.
+func (s *Session) Reset() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.messages = nil
+	s.reactions = nil
+	s.typing = nil
+	s.responses = nil
+}
+
+// SendMsg records a message, data is handled similarly to disgord's SendMsg.
+func (s *Session) SendMsg(ctx context.Context, channelID disgord.Snowflake, data ...interface{}) (*disgord.Message, error) {
+	params := &disgord.CreateMessageParams{}
+
+	var content []string
+	for _, v := range data {
+		switch t := v.(type) {
+		case nil:
+			continue
+		case *disgord.CreateMessageParams:
+			*params = *t
+		case disgord.CreateMessageParams:
+			*params = t
+		case *disgord.Embed:
+			params.Embed = t
+		case disgord.Embed:
+			params.Embed = &t
+		case string:
+			content = append(content, t)
+		case fmt.Stringer:
+			content = append(content, t.String())
+		default:
+			content = append(content, fmt.Sprint(t))
+		}
+	}
+
+	if len(content) > 0 {
+		params.Content = strings.Join(content, " ")
+	}
+
+	return s.CreateMessage(ctx, channelID, params)
+}
+
+// CreateMessage records a message.
+func (s *Session) CreateMessage(_ context.Context, channelID disgord.Snowflake, params *disgord.CreateMessageParams, _ ...disgord.Flag) (*disgord.Message, error) {
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.messages = append(s.messages, &Message{
+		ChannelID: channelID,
+		Content:   params.Content,
+		Embed:     params.Embed,
+		Params:    params,
+	})
+
+	s.nextID++
+	message := &disgord.Message{
+		ID:        s.nextID,
+		ChannelID: channelID,
+		Content:   params.Content,
+		Author:    s.user,
+	}
+	if params.Embed != nil {
+		message.Embeds = []*disgord.Embed{params.Embed}
+	}
+
+	return message, nil
+}
+
+// CreateReaction records a reaction.
+func (s *Session) CreateReaction(_ context.Context, channelID, messageID disgord.Snowflake, emoji interface{}, _ ...disgord.Flag) error {
+	if s.Err != nil {
+		return s.Err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.reactions = append(s.reactions, &Reaction{
+		ChannelID: channelID,
+		MessageID: messageID,
+		Emoji:     emoji,
+	})
+	return nil
+}
+
+// CreateDM returns a direct message channel for a user, the channel's ID is the user's ID.
+func (s *Session) CreateDM(_ context.Context, recipientID disgord.Snowflake, _ ...disgord.Flag) (*disgord.Channel, error) {
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	return &disgord.Channel{
+		ID:   recipientID,
+		Type: disgord.ChannelTypeDM,
+		Recipients: []*disgord.User{
+			{ID: recipientID},
+		},
+	}, nil
+}
+
+// TriggerTypingIndicator records a typing indicator.
+func (s *Session) TriggerTypingIndicator(_ context.Context, channelID disgord.Snowflake, _ ...disgord.Flag) error {
+	if s.Err != nil {
+		return s.Err
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	s.typing = append(s.typing, channelID)
+	return nil
+}
+
+// GetGuild returns a guild added using AddGuild.
+func (s *Session) GetGuild(_ context.Context, id disgord.Snowflake, _ ...disgord.Flag) (*disgord.Guild, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	guild, ok := s.guilds[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return guild, nil
+}
+
+// GetGuildRoles returns the roles of a guild added using AddGuild.
+func (s *Session) GetGuildRoles(_ context.Context, guildID disgord.Snowflake, _ ...disgord.Flag) ([]*disgord.Role, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	roles, ok := s.roles[guildID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return roles, nil
+}
+
+// GetChannel returns a channel added using AddChannel.
+func (s *Session) GetChannel(_ context.Context, channelID disgord.Snowflake, _ ...disgord.Flag) (*disgord.Channel, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	channel, ok := s.channels[channelID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return channel, nil
+}
+
+// GetMember returns a member added using AddMember.
+func (s *Session) GetMember(_ context.Context, guildID, userID disgord.Snowflake, _ ...disgord.Flag) (*disgord.Member, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	member, ok := s.members[guildID][userID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return member, nil
+}
+
+// GetCurrentUser returns the bot's user, it's ID is BotID.
+func (s *Session) GetCurrentUser(_ context.Context, _ ...disgord.Flag) (*disgord.User, error) {
+	return s.user, nil
+}
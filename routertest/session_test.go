@@ -0,0 +1,133 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package routertest
+
+import (
+	"context"
+	"errors"
+	"github.com/andersfylling/disgord"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// recorder records the failures of assertions instead of failing the test.
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(_ string, _ ...interface{}) {
+	r.failed = true
+}
+
+func TestSession_SendMsg(t *testing.T) {
+	a := assert.New(t)
+
+	session := NewSession()
+	embed := &disgord.Embed{Title: "Title"}
+
+	message, err := session.SendMsg(context.Background(), 5, "hello", "world", embed)
+	if !a.NoError(err) {
+		return
+	}
+	a.Equal("hello world", message.Content)
+
+	last := session.LastMessage()
+	if a.NotNil(last) {
+		a.EqualValues(5, last.ChannelID)
+		a.Equal("hello world", last.Content)
+		a.Equal(embed, last.Embed)
+	}
+
+	session.Reset()
+	a.Nil(session.LastMessage())
+
+	session.Err = errors.New("unavailable")
+	_, err = session.SendMsg(context.Background(), 5, "hello")
+	a.Equal(session.Err, err)
+	a.Empty(session.Messages())
+}
+
+func TestSession_State(t *testing.T) {
+	a := assert.New(t)
+
+	session := NewSession()
+	ctx := context.Background()
+
+	_, err := session.GetGuild(ctx, 1)
+	a.Equal(ErrNotFound, err)
+
+	session.AddGuild(&disgord.Guild{ID: 1}, &disgord.Role{ID: 1})
+	session.AddMember(1, &disgord.Member{User: DefaultAuthor})
+
+	guild, err := session.GetGuild(ctx, 1)
+	a.NoError(err)
+	a.EqualValues(1, guild.ID)
+
+	roles, err := session.GetGuildRoles(ctx, 1)
+	a.NoError(err)
+	a.Len(roles, 1)
+
+	member, err := session.GetMember(ctx, 1, DefaultAuthor.ID)
+	a.NoError(err)
+	a.Equal(DefaultAuthor, member.User)
+
+	user, err := session.GetCurrentUser(ctx)
+	a.NoError(err)
+	a.Equal(BotID, user.ID)
+}
+
+func TestNewMessage(t *testing.T) {
+	a := assert.New(t)
+
+	author := &disgord.User{ID: 5}
+	e := NewMessage("!ping").Author(author).Guild(1).Channel(2).Roles(3).Build()
+
+	a.Equal("!ping", e.Message.Content)
+	a.Equal(author, e.Message.Author)
+	a.EqualValues(1, e.Message.GuildID)
+	a.EqualValues(2, e.Message.ChannelID)
+	a.Equal([]disgord.Snowflake{3}, e.Message.Member.Roles)
+	a.NotNil(e.Ctx)
+
+	other := NewMessageCreate("!ping", nil)
+	a.Equal(DefaultAuthor, other.Message.Author)
+	a.Nil(other.Message.Member)
+	a.NotEqual(e.Message.ID, other.Message.ID)
+}
+
+func TestSession_Assert(t *testing.T) {
+	a := assert.New(t)
+
+	session := NewSession()
+	_, _ = session.SendMsg(context.Background(), 1, "hello")
+	_ = session.CreateReaction(context.Background(), 1, 2, "👍")
+
+	r := &recorder{TB: t}
+	a.True(session.AssertSent(r, "hello"))
+	a.True(session.AssertReacted(r, "👍"))
+	a.False(r.failed)
+
+	a.False(session.AssertSent(r, "goodbye"))
+	a.True(r.failed)
+
+	r = &recorder{TB: t}
+	a.False(session.AssertNothingSent(r))
+	a.False(session.AssertSentEmbed(r, "Title"))
+	a.False(AssertError(r, errors.New("oh no"), &testError{}))
+	a.True(r.failed)
+}
+
+// testError is used to test AssertError.
+type testError struct{}
+
+func (err *testError) Error() string {
+	return "not found"
+}
@@ -0,0 +1,216 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// nonCommandInterfaces are the interfaces whose methods are never reported as skipped commands.
+var nonCommandInterfaces = []reflect.Type{
+	reflect.TypeOf((*Registrar)(nil)).Elem(),
+	reflect.TypeOf((*MetadataRegistrar)(nil)).Elem(),
+	reflect.TypeOf((*GeneratedRegistrar)(nil)).Elem(),
+	reflect.TypeOf((*Module)(nil)).Elem(),
+	reflect.TypeOf((*ModuleInitializer)(nil)).Elem(),
+	reflect.TypeOf((*ModuleTeardowner)(nil)).Elem(),
+}
+
+// WithStrict makes registering commands fail with an *ErrStrict when a registrar
+// has a problem that would otherwise be ignored: exported methods that are skipped
+// because they are not commands, description, argument or metadata keys that do
+// not match a command, commands without a description and name collisions.
+//
+// Strict mode applies to New and every call to Register, RegisterModule
+// and RegisterCommands.
+func WithStrict() Option {
+	return func(r *Router) {
+		r.strict = true
+	}
+}
+
+// checkStrict returns an *ErrStrict listing every problem with the commands if
+// the router is in strict mode, registrar may be nil if the commands were not
+// created from a registrar.
+func (r *Router) checkStrict(registrar Registrar, commands []*Command) error {
+	if !r.strict {
+		return nil
+	}
+
+	var problems []string
+	if registrar != nil {
+		problems = append(problems, getSkippedMethods(registrar)...)
+		problems = append(problems, getOrphanKeys(registrar, commands)...)
+	}
+
+	for _, command := range commands {
+		if command.Description == "" {
+			problems = append(problems, fmt.Sprintf("%s does not have a description", command.name))
+		}
+	}
+
+	r.commandsMx.RLock()
+	problems = append(problems, r.getCollisions(commands)...)
+	r.commandsMx.RUnlock()
+
+	if len(problems) > 0 {
+		return &ErrStrict{
+			Problems: problems,
+		}
+	}
+
+	return nil
+}
+
+// getSkippedMethods returns a problem for every exported method on the registrar
+// that is skipped because it does not have a command signature.
+func getSkippedMethods(registrar Registrar) []string {
+	t := reflect.TypeOf(registrar)
+
+	ignored := make(map[string]struct{})
+	for _, iface := range nonCommandInterfaces {
+		if !t.Implements(iface) {
+			continue
+		}
+
+		for i := 0; i < iface.NumMethod(); i++ {
+			ignored[iface.Method(i).Name] = struct{}{}
+		}
+	}
+
+	var problems []string
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+		if _, ok := ignored[method.Name]; ok {
+			continue
+		}
+
+		if _, _, offset := getSignature(method.Type, 1); offset < 0 {
+			problems = append(problems, fmt.Sprintf("method %s was skipped because it does not take a platform's event, a *router.Context or a router.Message", method.Name))
+		}
+	}
+
+	return problems
+}
+
+// getOrphanKeys returns a problem for every description, argument and metadata key
+// that does not match a command, and every argument description that does not match
+// one of the command's arguments.
+func getOrphanKeys(registrar Registrar, commands []*Command) []string {
+	byName := make(map[string]*Command, len(commands))
+	for _, command := range commands {
+		byName[command.name] = command
+	}
+
+	var problems []string
+	orphans := func(kind string, keys []string) {
+		sort.Strings(keys)
+		for _, key := range keys {
+			if _, ok := byName[key]; !ok {
+				problems = append(problems, fmt.Sprintf("%s %s does not match a command", kind, key))
+			}
+		}
+	}
+
+	descriptions := make([]string, 0)
+	for key := range registrar.Descriptions() {
+		descriptions = append(descriptions, key)
+	}
+	orphans("description", descriptions)
+
+	arguments := make([]string, 0)
+	for key := range registrar.Arguments() {
+		arguments = append(arguments, key)
+	}
+	orphans("usage", arguments)
+
+	m, ok := registrar.(MetadataRegistrar)
+	if !ok {
+		return problems
+	}
+
+	metadata := m.Metadata()
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	orphans("metadata", keys)
+
+	for _, key := range keys {
+		command, ok := byName[key]
+		if !ok {
+			continue
+		}
+
+		names := make([]string, 0, len(metadata[key].ArgumentDescriptions))
+		for name := range metadata[key].ArgumentDescriptions {
+			names = append(names, name)
+		}
+		problems = append(problems, getOrphanArguments("argument description", command, names)...)
+
+		names = make([]string, 0, len(metadata[key].Choices))
+		for name := range metadata[key].Choices {
+			names = append(names, name)
+		}
+		problems = append(problems, getOrphanArguments("argument choices", command, names)...)
+	}
+
+	return problems
+}
+
+// getOrphanArguments returns a problem for every name that does not match an argument of the command.
+func getOrphanArguments(kind string, command *Command, names []string) []string {
+	sort.Strings(names)
+
+	var problems []string
+Names:
+	for _, name := range names {
+		for _, argument := range command.argumentInfo {
+			if argument.name == name {
+				continue Names
+			}
+		}
+
+		problems = append(problems, fmt.Sprintf("%s %s does not match an argument of %s", kind, name, command.name))
+	}
+
+	return problems
+}
+
+// getCollisions returns a problem for every name and alias of the commands that is
+// already registered or used by another of the commands.
+// r.commandsMx must be held by the caller.
+func (r *Router) getCollisions(commands []*Command) []string {
+	commandsByName := make(map[string]*Command, len(r.commandsByName)+len(commands))
+	for name, command := range r.commandsByName {
+		commandsByName[name] = command
+	}
+
+	var problems []string
+	for _, command := range commands {
+		if existing, ok := commandsByName[command.name]; ok {
+			problems = append(problems, alreadyRegistered(command.name, existing))
+			continue
+		}
+		commandsByName[command.name] = command
+	}
+
+	for _, command := range commands {
+		for _, alias := range command.aliases {
+			if existing, ok := commandsByName[alias]; ok {
+				problems = append(problems, alreadyRegistered(command.name+"'s alias "+alias, existing))
+				continue
+			}
+			commandsByName[alias] = command
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,103 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type sloppyRegistrar struct{}
+
+func (c *sloppyRegistrar) Kick(_ *testEvent, _ string) error {
+	return nil
+}
+
+func (c *sloppyRegistrar) Mute(_ *testEvent) error {
+	return nil
+}
+
+// Ban is skipped because it takes a *testMessage.
+func (c *sloppyRegistrar) Ban(_ *testMessage) error {
+	return nil
+}
+
+func (c *sloppyRegistrar) Descriptions() map[string]string {
+	return map[string]string{
+		"kick": "Kicks a user.",
+		"ban":  "Bans a user.",
+	}
+}
+
+func (c *sloppyRegistrar) Arguments() map[string][]string {
+	return map[string][]string{
+		"kick": {"user"},
+		"kcik": {"user"},
+	}
+}
+
+func (c *sloppyRegistrar) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"kick": {
+			Aliases:              []string{"k", "mute"},
+			ArgumentDescriptions: map[string]string{"usr": "The user to kick."},
+			Choices:              map[string][]*Choice{"usr": {{Name: "Nobody", Value: "0"}}},
+		},
+		"mutee": {},
+	}
+}
+
+func TestWithStrict(t *testing.T) {
+	t.Run("Problems", func(t *testing.T) {
+		a := assert.New(t)
+
+		_, err := New(prefix, &sloppyRegistrar{}, WithStrict())
+		if !a.IsType(&ErrStrict{}, err) {
+			return
+		}
+
+		a.Equal([]string{
+			"method Ban was skipped because it does not take a platform's event, a *router.Context or a router.Message",
+			"description ban does not match a command",
+			"usage kcik does not match a command",
+			"metadata mutee does not match a command",
+			"argument description usr does not match an argument of kick",
+			"argument choices usr does not match an argument of kick",
+			"mute does not have a description",
+			"kick's alias mute is already registered",
+		}, err.(*ErrStrict).Problems)
+	})
+
+	t.Run("Lenient", func(t *testing.T) {
+		a := assert.New(t)
+
+		// Without strict mode only the alias collision is reported.
+		_, err := New(prefix, &sloppyRegistrar{})
+		a.EqualError(err, "router: kick's alias mute is already registered")
+	})
+
+	t.Run("Register", func(t *testing.T) {
+		a := assert.New(t)
+
+		router, err := New(prefix, &pluginRegistrar{}, WithStrict())
+		if !a.IsType(&ErrStrict{}, err) {
+			return
+		}
+		a.Nil(router)
+
+		router, err = New(prefix, &banRegistrar{}, WithStrict())
+		if !a.NoError(err) {
+			return
+		}
+
+		err = router.Register(&banRegistrar{})
+		if a.IsType(&ErrStrict{}, err) {
+			a.Equal([]string{"ban is already registered"}, err.(*ErrStrict).Problems)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"strings"
+)
+
+// Message represents a message received from a chat platform.
+type Message interface {
+	// Content returns the text of the message.
+	Content() string
+	// AuthorID returns the ID of the user that sent the message.
+	AuthorID() string
+	// ChannelID returns the ID of the channel the message was sent in.
+	ChannelID() string
+	// GuildID returns the ID of the guild, server or workspace the message was
+	// sent in, the ID is empty if the message is a direct message.
+	GuildID() string
+}
+
+// Transport represents a chat platform the router receives messages from.
+type Transport interface {
+	// Reply sends a message to the channel the message was sent in.
+	Reply(ctx context.Context, m Message, content string) error
+}
+
+// ReplyTransport represents a transport that can send replies other than text,
+// such as the replies of it's platform.
+type ReplyTransport interface {
+	Transport
+	// SendReply sends a reply to the channel the message was sent in, data is
+	// either the reply returned by a command or the data passed to Context#Reply.
+	SendReply(ctx context.Context, m Message, data ...interface{}) error
+}
+
+// CheckTransport represents a transport that checks the restrictions that
+// depend on it's platform, such as permissions, roles and NSFW channels.
+type CheckTransport interface {
+	Transport
+	// Check returns nil if the author of the message is allowed to run the
+	// command, it's called after the router's own restrictions are checked.
+	Check(ctx context.Context, m Message, command *Command) error
+}
+
+// FinishTransport represents a transport that is told when a command has
+// finished running, such as to acknowledge the interaction that ran it.
+type FinishTransport interface {
+	Transport
+	// Finish is called once the command has returned, err is the command's error.
+	// The error returned by Finish is returned if the command did not fail.
+	Finish(ctx context.Context, m Message, err error) error
+}
+
+// TransportFunc is an adapter to allow the use of an ordinary function as a Transport.
+type TransportFunc func(ctx context.Context, m Message, content string) error
+
+// Reply calls f(ctx, m, content).
+func (f TransportFunc) Reply(ctx context.Context, m Message, content string) error {
+	return f(ctx, m, content)
+}
+
+// HandleMessage handles a message received by a transport.
+//
+// Any panic while parsing arguments or running the command is recovered and
+// returned as an *ErrCommandPanic.
+//
+// When the router has a worker pool, the command is parsed before HandleMessage
+// returns and executed by the pool, errors from the execution are passed to the
+// pool's OnError callback.
+//
+// Commands that take a platform's event, or that have restrictions that can only
+// be checked by a CheckTransport, return an *ErrUnsupportedTransport when the
+// message was received by a transport that cannot run them.
+func (r *Router) HandleMessage(ctx context.Context, t Transport, m Message) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	done, err := r.begin()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	x, err := r.prepare(ctx, t, m)
+	if err != nil {
+		return err
+	}
+
+	return r.dispatch(x)
+}
+
+// HandleCommand handles a command invoked by name with it's arguments bound by
+// name, such as a Discord application command. arguments maps an argument's name
+// to it's input, names are case insensitive and optional arguments may be omitted.
+//
+// The message is passed to the command and it's restrictions are checked the
+// same way as HandleMessage, the command's raw arguments are the inputs joined
+// by spaces.
+func (r *Router) HandleCommand(ctx context.Context, t Transport, m Message, name string, arguments map[string]string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	done, err := r.begin()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	// Check if the user is sending commands too quickly.
+	if err := r.checkRateLimit(m); err != nil {
+		return err
+	}
+
+	name = strings.ToLower(name)
+	command := r.GetCommandByName(name)
+	if command == nil {
+		return &ErrUnknownCommand{
+			Command: name,
+		}
+	}
+
+	x, err := r.prepareCommand(ctx, t, m, command, func() ([]reflect.Value, string, error) {
+		return getNamedArgumentValues(r.Prefix, command, arguments)
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.dispatch(x)
+}
+
+// sendReply sends a reply using the transport, transports that do not implement
+// ReplyTransport can only send text.
+func sendReply(ctx context.Context, t Transport, m Message, data ...interface{}) error {
+	if rt, ok := t.(ReplyTransport); ok {
+		return rt.SendReply(ctx, m, data...)
+	}
+
+	content, ok := getReplyContent(data...)
+	if !ok {
+		return ErrUnsupportedReply
+	}
+
+	return t.Reply(ctx, m, content)
+}
+
+// getReplyContent joins strings and fmt.Stringers in data by spaces, false is
+// returned if data contains anything else or there is nothing to send.
+func getReplyContent(data ...interface{}) (string, bool) {
+	content := make([]string, 0, len(data))
+	for _, v := range data {
+		switch t := v.(type) {
+		case nil:
+			continue
+		case string:
+			content = append(content, t)
+		case fmt.Stringer:
+			content = append(content, t.String())
+		default:
+			return "", false
+		}
+	}
+
+	if len(content) < 1 {
+		return "", false
+	}
+
+	return strings.Join(content, " "), true
+}
+
+// hashString returns a hash of s, used to spread messages across the worker pool.
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
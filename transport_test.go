@@ -0,0 +1,257 @@
+//
+// Copyright (c) 2020 Matthew Penner <me@matthewp.io>
+//
+// This repository is licensed under the MIT License.
+// https://github.com/matthewpi/router/blob/master/LICENSE.md
+//
+
+package router
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// testMessage represents a message received from a transport without a platform.
+type testMessage struct {
+	content string
+	author  string
+	channel string
+	guild   string
+}
+
+func newTestMessage(content string) *testMessage {
+	return &testMessage{
+		content: content,
+		author:  "alice",
+		channel: "#general",
+	}
+}
+
+func (m *testMessage) Content() string {
+	return m.content
+}
+
+func (m *testMessage) AuthorID() string {
+	return m.author
+}
+
+func (m *testMessage) ChannelID() string {
+	return m.channel
+}
+
+func (m *testMessage) GuildID() string {
+	return m.guild
+}
+
+// testEvent represents the event of the platform registered by the tests.
+type testEvent struct {
+	*testMessage
+	Ctx context.Context
+}
+
+func newTestEvent(content string) *testEvent {
+	return &testEvent{testMessage: newTestMessage(content)}
+}
+
+// testEmbed represents a reply of the platform registered by the tests.
+type testEmbed struct {
+	Title string
+}
+
+// The permissions of the platform registered by the tests.
+const (
+	permissionKickMembers    uint64 = 1 << 1
+	permissionBanMembers     uint64 = 1 << 2
+	permissionManageMessages uint64 = 1 << 13
+)
+
+func init() {
+	RegisterPlatform(Platform{
+		Event: reflect.TypeOf((*testEvent)(nil)),
+		GetEvent: func(ctx context.Context, m Message) (interface{}, bool) {
+			e, ok := m.(*testEvent)
+			if !ok {
+				return nil, false
+			}
+
+			ev := *e
+			ev.Ctx = ctx
+			return &ev, true
+		},
+		Replies: []reflect.Type{reflect.TypeOf((*testEmbed)(nil))},
+		PermissionNames: func(permissions uint64) []string {
+			var names []string
+			for _, p := range []struct {
+				bit  uint64
+				name string
+			}{
+				{permissionKickMembers, "Kick Members"},
+				{permissionBanMembers, "Ban Members"},
+				{permissionManageMessages, "Manage Messages"},
+			} {
+				if permissions&p.bit == p.bit {
+					names = append(names, p.name)
+				}
+			}
+
+			return names
+		},
+	})
+}
+
+// testTransport records the replies it sends, check checks the platform's
+// restrictions if it is set.
+type testTransport struct {
+	mx      sync.Mutex
+	replies []interface{}
+	check   func(m Message, command *Command) error
+}
+
+func (t *testTransport) Reply(ctx context.Context, m Message, content string) error {
+	return t.SendReply(ctx, m, content)
+}
+
+func (t *testTransport) SendReply(_ context.Context, _ Message, data ...interface{}) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	t.replies = append(t.replies, data...)
+	return nil
+}
+
+func (t *testTransport) Check(_ context.Context, m Message, command *Command) error {
+	if t.check == nil {
+		return nil
+	}
+
+	return t.check(m, command)
+}
+
+func (t *testTransport) Replies() []interface{} {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	return append([]interface{}(nil), t.replies...)
+}
+
+// handle handles the message using a new testTransport.
+func handle(r *Router, m Message) error {
+	return r.HandleMessage(context.Background(), &testTransport{}, m)
+}
+
+type portableCommands struct{}
+
+func (c *portableCommands) Echo(m Message, text string) (string, error) {
+	return m.AuthorID() + ": " + text, nil
+}
+
+func (c *portableCommands) Wait(ctx context.Context, _ Message) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+
+	return "done", nil
+}
+
+func (c *portableCommands) Embed(_ Message) (*testEmbed, error) {
+	return &testEmbed{Title: "Embed"}, nil
+}
+
+func (c *portableCommands) Server(_ Message) (string, error) {
+	return "server", nil
+}
+
+func (c *portableCommands) Platform(_ *testEvent) (string, error) {
+	return "platform", nil
+}
+
+func (c *portableCommands) Purge(_ Message) error {
+	return nil
+}
+
+func (c *portableCommands) Descriptions() map[string]string {
+	return map[string]string{}
+}
+
+func (c *portableCommands) Arguments() map[string][]string {
+	return map[string][]string{
+		"echo": {"text"},
+	}
+}
+
+func (c *portableCommands) Metadata() map[string]Metadata {
+	return map[string]Metadata{
+		"server": {
+			Restrictions: Restrictions{GuildOnly: true},
+		},
+		"purge": {
+			Permissions: permissionManageMessages,
+		},
+	}
+}
+
+func TestRouter_HandleMessage(t *testing.T) {
+	var replies []string
+	transport := TransportFunc(func(_ context.Context, m Message, content string) error {
+		replies = append(replies, m.ChannelID()+" "+content)
+		return nil
+	})
+
+	router, err := New(prefix, &portableCommands{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Reply", func(t *testing.T) {
+		a := assert.New(t)
+
+		replies = nil
+		a.NoError(router.HandleMessage(context.Background(), transport, newTestMessage(".echo hello")))
+		a.NoError(router.HandleMessage(context.Background(), transport, newTestMessage(".wait")))
+		a.Equal([]string{"#general alice: hello", "#general done"}, replies)
+	})
+
+	t.Run("Restrictions", func(t *testing.T) {
+		a := assert.New(t)
+
+		a.IsType(&ErrGuildOnly{}, router.HandleMessage(context.Background(), transport, newTestMessage(".server")))
+		a.NoError(router.HandleMessage(context.Background(), transport, &testMessage{content: ".server", author: "alice", channel: "#general", guild: "acme"}))
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		a := assert.New(t)
+
+		a.IsType(&ErrUnsupportedTransport{}, router.HandleMessage(context.Background(), transport, newTestMessage(".platform")))
+		a.IsType(&ErrUnsupportedTransport{}, router.HandleMessage(context.Background(), transport, newTestMessage(".purge")))
+
+		err := router.HandleMessage(context.Background(), transport, newTestMessage(".embed"))
+		if a.IsType(&ErrCommandExecution{}, err) {
+			a.Equal(ErrUnsupportedReply, err.(*ErrCommandExecution).err)
+		}
+	})
+
+}
+
+func TestRouter_HandleMessage_SendReply(t *testing.T) {
+	a := assert.New(t)
+
+	router, err := New(prefix, &portableCommands{})
+	if !a.NoError(err) {
+		return
+	}
+
+	// Commands that take a Message can also be ran from a platform.
+	transport := &testTransport{}
+	e := newTestEvent(".echo hello")
+	e.author = "42"
+	a.NoError(router.HandleMessage(context.Background(), transport, e))
+
+	// Replies only the platform can send are passed to the transport as is.
+	a.NoError(router.HandleMessage(context.Background(), transport, newTestEvent(".embed")))
+
+	a.Equal([]interface{}{"42: hello", &testEmbed{Title: "Embed"}}, transport.Replies())
+}
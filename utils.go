@@ -11,9 +11,10 @@ import (
 	"reflect"
 )
 
-// callWith calls a caller using the specified arguments.
-func callWith(caller reflect.Value, ev interface{}, values ...reflect.Value) error {
-	return errorReturns(
+// callWith calls a caller using the specified arguments, returning the reply
+// if the caller returns one.
+func callWith(caller reflect.Value, ev interface{}, values ...reflect.Value) (interface{}, error) {
+	return replyReturns(
 		caller.Call(
 			append(
 				[]reflect.Value{reflect.ValueOf(ev)},
@@ -23,6 +24,15 @@ func callWith(caller reflect.Value, ev interface{}, values ...reflect.Value) err
 	)
 }
 
+// replyReturns handles the reflection of fetching a reply and an error from a method call's return values.
+func replyReturns(returns []reflect.Value) (interface{}, error) {
+	if len(returns) < 2 {
+		return nil, errorReturns(returns)
+	}
+
+	return returns[0].Interface(), errorReturns(returns[1:])
+}
+
 // errorReturns handles the reflection of fetching an error from a method call's return values.
 func errorReturns(returns []reflect.Value) error {
 	// WARNING: This assumes that the first return value is an error!
@@ -49,3 +59,17 @@ func quickRet(v interface{}, err error, t reflect.Type) (reflect.Value, error) {
 
 	return rv.Convert(t), nil
 }
+
+// truncate shortens s to at most n characters, ending it with an ellipsis if it was shortened.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	if n <= 3 {
+		return string(runes[:n])
+	}
+
+	return string(runes[:n-3]) + "..."
+}